@@ -13,6 +13,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	_ "google.golang.org/protobuf/types/descriptorpb"
 	_ "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -276,32 +277,592 @@ func (x *SignUpCallResponse) GetResult() *SignUpCallResponse_Result {
 	return nil
 }
 
+type GetConfigCallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Id   string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Meta *Meta  `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
+}
+
+func (x *GetConfigCallRequest) Reset() {
+	*x = GetConfigCallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigCallRequest) ProtoMessage() {}
+
+func (x *GetConfigCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigCallRequest.ProtoReflect.Descriptor instead.
+func (*GetConfigCallRequest) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetConfigCallRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetConfigCallRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetConfigCallRequest) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+type GetConfigCallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string                        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result *GetConfigCallResponse_Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *GetConfigCallResponse) Reset() {
+	*x = GetConfigCallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetConfigCallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConfigCallResponse) ProtoMessage() {}
+
+func (x *GetConfigCallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConfigCallResponse.ProtoReflect.Descriptor instead.
+func (*GetConfigCallResponse) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetConfigCallResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetConfigCallResponse) GetResult() *GetConfigCallResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type SubscribeEventsCallRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string                             `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Id     string                             `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Meta   *Meta                              `protobuf:"bytes,3,opt,name=meta,proto3" json:"meta,omitempty"`
+	Params *SubscribeEventsCallRequest_Params `protobuf:"bytes,4,opt,name=params,proto3" json:"params,omitempty"`
+}
+
+func (x *SubscribeEventsCallRequest) Reset() {
+	*x = SubscribeEventsCallRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEventsCallRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsCallRequest) ProtoMessage() {}
+
+func (x *SubscribeEventsCallRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsCallRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsCallRequest) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SubscribeEventsCallRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubscribeEventsCallRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubscribeEventsCallRequest) GetMeta() *Meta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *SubscribeEventsCallRequest) GetParams() *SubscribeEventsCallRequest_Params {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type SubscribeEventsCallResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string                              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Result *SubscribeEventsCallResponse_Result `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *SubscribeEventsCallResponse) Reset() {
+	*x = SubscribeEventsCallResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeEventsCallResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEventsCallResponse) ProtoMessage() {}
+
+func (x *SubscribeEventsCallResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEventsCallResponse.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsCallResponse) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SubscribeEventsCallResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubscribeEventsCallResponse) GetResult() *SubscribeEventsCallResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
 type SignInCallRequest_Params struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email    string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Email    string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *SignInCallRequest_Params) Reset() {
+	*x = SignInCallRequest_Params{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInCallRequest_Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInCallRequest_Params) ProtoMessage() {}
+
+func (x *SignInCallRequest_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInCallRequest_Params.ProtoReflect.Descriptor instead.
+func (*SignInCallRequest_Params) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *SignInCallRequest_Params) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SignInCallRequest_Params) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type SignInCallResponse_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*SignInCallResponse_Result_Success_
+	//	*SignInCallResponse_Result_Failure
+	Result isSignInCallResponse_Result_Result `protobuf_oneof:"result"`
+}
+
+func (x *SignInCallResponse_Result) Reset() {
+	*x = SignInCallResponse_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInCallResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInCallResponse_Result) ProtoMessage() {}
+
+func (x *SignInCallResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInCallResponse_Result.ProtoReflect.Descriptor instead.
+func (*SignInCallResponse_Result) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{1, 0}
+}
+
+func (m *SignInCallResponse_Result) GetResult() isSignInCallResponse_Result_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *SignInCallResponse_Result) GetSuccess() *SignInCallResponse_Result_Success {
+	if x, ok := x.GetResult().(*SignInCallResponse_Result_Success_); ok {
+		return x.Success
+	}
+	return nil
+}
+
+func (x *SignInCallResponse_Result) GetFailure() *Failure {
+	if x, ok := x.GetResult().(*SignInCallResponse_Result_Failure); ok {
+		return x.Failure
+	}
+	return nil
+}
+
+type isSignInCallResponse_Result_Result interface {
+	isSignInCallResponse_Result_Result()
+}
+
+type SignInCallResponse_Result_Success_ struct {
+	Success *SignInCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type SignInCallResponse_Result_Failure struct {
+	Failure *Failure `protobuf:"bytes,2,opt,name=failure,proto3,oneof"`
+}
+
+func (*SignInCallResponse_Result_Success_) isSignInCallResponse_Result_Result() {}
+
+func (*SignInCallResponse_Result_Failure) isSignInCallResponse_Result_Result() {}
+
+type SignInCallResponse_Result_Success struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+}
+
+func (x *SignInCallResponse_Result_Success) Reset() {
+	*x = SignInCallResponse_Result_Success{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignInCallResponse_Result_Success) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignInCallResponse_Result_Success) ProtoMessage() {}
+
+func (x *SignInCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignInCallResponse_Result_Success.ProtoReflect.Descriptor instead.
+func (*SignInCallResponse_Result_Success) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{1, 0, 0}
+}
+
+func (x *SignInCallResponse_Result_Success) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SignUpCallRequest_Params struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Email    string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+}
+
+func (x *SignUpCallRequest_Params) Reset() {
+	*x = SignUpCallRequest_Params{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignUpCallRequest_Params) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUpCallRequest_Params) ProtoMessage() {}
+
+func (x *SignUpCallRequest_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUpCallRequest_Params.ProtoReflect.Descriptor instead.
+func (*SignUpCallRequest_Params) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{2, 0}
+}
+
+func (x *SignUpCallRequest_Params) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *SignUpCallRequest_Params) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type SignUpCallResponse_Result struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Result:
+	//	*SignUpCallResponse_Result_Success_
+	//	*SignUpCallResponse_Result_Failure
+	Result isSignUpCallResponse_Result_Result `protobuf_oneof:"result"`
+}
+
+func (x *SignUpCallResponse_Result) Reset() {
+	*x = SignUpCallResponse_Result{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_calls_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignUpCallResponse_Result) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignUpCallResponse_Result) ProtoMessage() {}
+
+func (x *SignUpCallResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignUpCallResponse_Result.ProtoReflect.Descriptor instead.
+func (*SignUpCallResponse_Result) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (m *SignUpCallResponse_Result) GetResult() isSignUpCallResponse_Result_Result {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (x *SignUpCallResponse_Result) GetSuccess() *SignUpCallResponse_Result_Success {
+	if x, ok := x.GetResult().(*SignUpCallResponse_Result_Success_); ok {
+		return x.Success
+	}
+	return nil
+}
+
+func (x *SignUpCallResponse_Result) GetFailure() *Failure {
+	if x, ok := x.GetResult().(*SignUpCallResponse_Result_Failure); ok {
+		return x.Failure
+	}
+	return nil
+}
+
+type isSignUpCallResponse_Result_Result interface {
+	isSignUpCallResponse_Result_Result()
+}
+
+type SignUpCallResponse_Result_Success_ struct {
+	Success *SignUpCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type SignUpCallResponse_Result_Failure struct {
+	Failure *Failure `protobuf:"bytes,2,opt,name=failure,proto3,oneof"`
+}
+
+func (*SignUpCallResponse_Result_Success_) isSignUpCallResponse_Result_Result() {}
+
+func (*SignUpCallResponse_Result_Failure) isSignUpCallResponse_Result_Result() {}
+
+type SignUpCallResponse_Result_Success struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
 }
 
-func (x *SignInCallRequest_Params) Reset() {
-	*x = SignInCallRequest_Params{}
+func (x *SignUpCallResponse_Result_Success) Reset() {
+	*x = SignUpCallResponse_Result_Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[4]
+		mi := &file_calls_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignInCallRequest_Params) String() string {
+func (x *SignUpCallResponse_Result_Success) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInCallRequest_Params) ProtoMessage() {}
+func (*SignUpCallResponse_Result_Success) ProtoMessage() {}
 
-func (x *SignInCallRequest_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[4]
+func (x *SignUpCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,54 +873,46 @@ func (x *SignInCallRequest_Params) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInCallRequest_Params.ProtoReflect.Descriptor instead.
-func (*SignInCallRequest_Params) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{0, 0}
-}
-
-func (x *SignInCallRequest_Params) GetEmail() string {
-	if x != nil {
-		return x.Email
-	}
-	return ""
+// Deprecated: Use SignUpCallResponse_Result_Success.ProtoReflect.Descriptor instead.
+func (*SignUpCallResponse_Result_Success) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{3, 0, 0}
 }
 
-func (x *SignInCallRequest_Params) GetPassword() string {
+func (x *SignUpCallResponse_Result_Success) GetToken() string {
 	if x != nil {
-		return x.Password
+		return x.Token
 	}
 	return ""
 }
 
-type SignInCallResponse_Result struct {
+type GetConfigCallResponse_Result struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Result:
-	//
-	//	*SignInCallResponse_Result_Success_
-	//	*SignInCallResponse_Result_Failure
-	Result isSignInCallResponse_Result_Result `protobuf_oneof:"result"`
+	//	*GetConfigCallResponse_Result_Success_
+	//	*GetConfigCallResponse_Result_Failure
+	Result isGetConfigCallResponse_Result_Result `protobuf_oneof:"result"`
 }
 
-func (x *SignInCallResponse_Result) Reset() {
-	*x = SignInCallResponse_Result{}
+func (x *GetConfigCallResponse_Result) Reset() {
+	*x = GetConfigCallResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[5]
+		mi := &file_calls_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignInCallResponse_Result) String() string {
+func (x *GetConfigCallResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInCallResponse_Result) ProtoMessage() {}
+func (*GetConfigCallResponse_Result) ProtoMessage() {}
 
-func (x *SignInCallResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[5]
+func (x *GetConfigCallResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -370,73 +923,74 @@ func (x *SignInCallResponse_Result) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInCallResponse_Result.ProtoReflect.Descriptor instead.
-func (*SignInCallResponse_Result) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{1, 0}
+// Deprecated: Use GetConfigCallResponse_Result.ProtoReflect.Descriptor instead.
+func (*GetConfigCallResponse_Result) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{5, 0}
 }
 
-func (m *SignInCallResponse_Result) GetResult() isSignInCallResponse_Result_Result {
+func (m *GetConfigCallResponse_Result) GetResult() isGetConfigCallResponse_Result_Result {
 	if m != nil {
 		return m.Result
 	}
 	return nil
 }
 
-func (x *SignInCallResponse_Result) GetSuccess() *SignInCallResponse_Result_Success {
-	if x, ok := x.GetResult().(*SignInCallResponse_Result_Success_); ok {
+func (x *GetConfigCallResponse_Result) GetSuccess() *GetConfigCallResponse_Result_Success {
+	if x, ok := x.GetResult().(*GetConfigCallResponse_Result_Success_); ok {
 		return x.Success
 	}
 	return nil
 }
 
-func (x *SignInCallResponse_Result) GetFailure() *Failure {
-	if x, ok := x.GetResult().(*SignInCallResponse_Result_Failure); ok {
+func (x *GetConfigCallResponse_Result) GetFailure() *Failure {
+	if x, ok := x.GetResult().(*GetConfigCallResponse_Result_Failure); ok {
 		return x.Failure
 	}
 	return nil
 }
 
-type isSignInCallResponse_Result_Result interface {
-	isSignInCallResponse_Result_Result()
+type isGetConfigCallResponse_Result_Result interface {
+	isGetConfigCallResponse_Result_Result()
 }
 
-type SignInCallResponse_Result_Success_ struct {
-	Success *SignInCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+type GetConfigCallResponse_Result_Success_ struct {
+	Success *GetConfigCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
 }
 
-type SignInCallResponse_Result_Failure struct {
+type GetConfigCallResponse_Result_Failure struct {
 	Failure *Failure `protobuf:"bytes,2,opt,name=failure,proto3,oneof"`
 }
 
-func (*SignInCallResponse_Result_Success_) isSignInCallResponse_Result_Result() {}
+func (*GetConfigCallResponse_Result_Success_) isGetConfigCallResponse_Result_Result() {}
 
-func (*SignInCallResponse_Result_Failure) isSignInCallResponse_Result_Result() {}
+func (*GetConfigCallResponse_Result_Failure) isGetConfigCallResponse_Result_Result() {}
 
-type SignInCallResponse_Result_Success struct {
+type GetConfigCallResponse_Result_Success struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Effective config with secrets redacted
+	Config *structpb.Struct `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
 }
 
-func (x *SignInCallResponse_Result_Success) Reset() {
-	*x = SignInCallResponse_Result_Success{}
+func (x *GetConfigCallResponse_Result_Success) Reset() {
+	*x = GetConfigCallResponse_Result_Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[6]
+		mi := &file_calls_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignInCallResponse_Result_Success) String() string {
+func (x *GetConfigCallResponse_Result_Success) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignInCallResponse_Result_Success) ProtoMessage() {}
+func (*GetConfigCallResponse_Result_Success) ProtoMessage() {}
 
-func (x *SignInCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[6]
+func (x *GetConfigCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,44 +1001,45 @@ func (x *SignInCallResponse_Result_Success) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignInCallResponse_Result_Success.ProtoReflect.Descriptor instead.
-func (*SignInCallResponse_Result_Success) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{1, 0, 0}
+// Deprecated: Use GetConfigCallResponse_Result_Success.ProtoReflect.Descriptor instead.
+func (*GetConfigCallResponse_Result_Success) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{5, 0, 0}
 }
 
-func (x *SignInCallResponse_Result_Success) GetToken() string {
+func (x *GetConfigCallResponse_Result_Success) GetConfig() *structpb.Struct {
 	if x != nil {
-		return x.Token
+		return x.Config
 	}
-	return ""
+	return nil
 }
 
-type SignUpCallRequest_Params struct {
+type SubscribeEventsCallRequest_Params struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Email    string `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
-	Password string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Topics []string `protobuf:"bytes,1,rep,name=topics,proto3" json:"topics,omitempty"`
+	// Resumes after this event when it is still in replay buffer
+	LastEventId *uint64 `protobuf:"varint,2,opt,name=last_event_id,json=lastEventId,proto3,oneof" json:"last_event_id,omitempty"`
 }
 
-func (x *SignUpCallRequest_Params) Reset() {
-	*x = SignUpCallRequest_Params{}
+func (x *SubscribeEventsCallRequest_Params) Reset() {
+	*x = SubscribeEventsCallRequest_Params{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[7]
+		mi := &file_calls_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignUpCallRequest_Params) String() string {
+func (x *SubscribeEventsCallRequest_Params) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignUpCallRequest_Params) ProtoMessage() {}
+func (*SubscribeEventsCallRequest_Params) ProtoMessage() {}
 
-func (x *SignUpCallRequest_Params) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[7]
+func (x *SubscribeEventsCallRequest_Params) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -495,54 +1050,53 @@ func (x *SignUpCallRequest_Params) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignUpCallRequest_Params.ProtoReflect.Descriptor instead.
-func (*SignUpCallRequest_Params) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{2, 0}
+// Deprecated: Use SubscribeEventsCallRequest_Params.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsCallRequest_Params) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{6, 0}
 }
 
-func (x *SignUpCallRequest_Params) GetEmail() string {
+func (x *SubscribeEventsCallRequest_Params) GetTopics() []string {
 	if x != nil {
-		return x.Email
+		return x.Topics
 	}
-	return ""
+	return nil
 }
 
-func (x *SignUpCallRequest_Params) GetPassword() string {
-	if x != nil {
-		return x.Password
+func (x *SubscribeEventsCallRequest_Params) GetLastEventId() uint64 {
+	if x != nil && x.LastEventId != nil {
+		return *x.LastEventId
 	}
-	return ""
+	return 0
 }
 
-type SignUpCallResponse_Result struct {
+type SubscribeEventsCallResponse_Result struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	// Types that are assignable to Result:
-	//
-	//	*SignUpCallResponse_Result_Success_
-	//	*SignUpCallResponse_Result_Failure
-	Result isSignUpCallResponse_Result_Result `protobuf_oneof:"result"`
+	//	*SubscribeEventsCallResponse_Result_Success_
+	//	*SubscribeEventsCallResponse_Result_Failure
+	Result isSubscribeEventsCallResponse_Result_Result `protobuf_oneof:"result"`
 }
 
-func (x *SignUpCallResponse_Result) Reset() {
-	*x = SignUpCallResponse_Result{}
+func (x *SubscribeEventsCallResponse_Result) Reset() {
+	*x = SubscribeEventsCallResponse_Result{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[8]
+		mi := &file_calls_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignUpCallResponse_Result) String() string {
+func (x *SubscribeEventsCallResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignUpCallResponse_Result) ProtoMessage() {}
+func (*SubscribeEventsCallResponse_Result) ProtoMessage() {}
 
-func (x *SignUpCallResponse_Result) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[8]
+func (x *SubscribeEventsCallResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,73 +1107,78 @@ func (x *SignUpCallResponse_Result) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignUpCallResponse_Result.ProtoReflect.Descriptor instead.
-func (*SignUpCallResponse_Result) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use SubscribeEventsCallResponse_Result.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsCallResponse_Result) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{7, 0}
 }
 
-func (m *SignUpCallResponse_Result) GetResult() isSignUpCallResponse_Result_Result {
+func (m *SubscribeEventsCallResponse_Result) GetResult() isSubscribeEventsCallResponse_Result_Result {
 	if m != nil {
 		return m.Result
 	}
 	return nil
 }
 
-func (x *SignUpCallResponse_Result) GetSuccess() *SignUpCallResponse_Result_Success {
-	if x, ok := x.GetResult().(*SignUpCallResponse_Result_Success_); ok {
+func (x *SubscribeEventsCallResponse_Result) GetSuccess() *SubscribeEventsCallResponse_Result_Success {
+	if x, ok := x.GetResult().(*SubscribeEventsCallResponse_Result_Success_); ok {
 		return x.Success
 	}
 	return nil
 }
 
-func (x *SignUpCallResponse_Result) GetFailure() *Failure {
-	if x, ok := x.GetResult().(*SignUpCallResponse_Result_Failure); ok {
+func (x *SubscribeEventsCallResponse_Result) GetFailure() *Failure {
+	if x, ok := x.GetResult().(*SubscribeEventsCallResponse_Result_Failure); ok {
 		return x.Failure
 	}
 	return nil
 }
 
-type isSignUpCallResponse_Result_Result interface {
-	isSignUpCallResponse_Result_Result()
+type isSubscribeEventsCallResponse_Result_Result interface {
+	isSubscribeEventsCallResponse_Result_Result()
 }
 
-type SignUpCallResponse_Result_Success_ struct {
-	Success *SignUpCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+type SubscribeEventsCallResponse_Result_Success_ struct {
+	Success *SubscribeEventsCallResponse_Result_Success `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
 }
 
-type SignUpCallResponse_Result_Failure struct {
+type SubscribeEventsCallResponse_Result_Failure struct {
 	Failure *Failure `protobuf:"bytes,2,opt,name=failure,proto3,oneof"`
 }
 
-func (*SignUpCallResponse_Result_Success_) isSignUpCallResponse_Result_Result() {}
+func (*SubscribeEventsCallResponse_Result_Success_) isSubscribeEventsCallResponse_Result_Result() {}
 
-func (*SignUpCallResponse_Result_Failure) isSignUpCallResponse_Result_Result() {}
+func (*SubscribeEventsCallResponse_Result_Failure) isSubscribeEventsCallResponse_Result_Result() {}
 
-type SignUpCallResponse_Result_Success struct {
+type SubscribeEventsCallResponse_Result_Success struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	EventId uint64          `protobuf:"varint,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Topic   string          `protobuf:"bytes,2,opt,name=topic,proto3" json:"topic,omitempty"`
+	Data    *structpb.Value `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	// Events after last_event_id were evicted from replay buffer,
+	// sent once before new events, client should reload its state
+	Reset_ bool `protobuf:"varint,4,opt,name=reset,proto3" json:"reset,omitempty"`
 }
 
-func (x *SignUpCallResponse_Result_Success) Reset() {
-	*x = SignUpCallResponse_Result_Success{}
+func (x *SubscribeEventsCallResponse_Result_Success) Reset() {
+	*x = SubscribeEventsCallResponse_Result_Success{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_calls_proto_msgTypes[9]
+		mi := &file_calls_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SignUpCallResponse_Result_Success) String() string {
+func (x *SubscribeEventsCallResponse_Result_Success) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SignUpCallResponse_Result_Success) ProtoMessage() {}
+func (*SubscribeEventsCallResponse_Result_Success) ProtoMessage() {}
 
-func (x *SignUpCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
-	mi := &file_calls_proto_msgTypes[9]
+func (x *SubscribeEventsCallResponse_Result_Success) ProtoReflect() protoreflect.Message {
+	mi := &file_calls_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -630,18 +1189,39 @@ func (x *SignUpCallResponse_Result_Success) ProtoReflect() protoreflect.Message
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SignUpCallResponse_Result_Success.ProtoReflect.Descriptor instead.
-func (*SignUpCallResponse_Result_Success) Descriptor() ([]byte, []int) {
-	return file_calls_proto_rawDescGZIP(), []int{3, 0, 0}
+// Deprecated: Use SubscribeEventsCallResponse_Result_Success.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsCallResponse_Result_Success) Descriptor() ([]byte, []int) {
+	return file_calls_proto_rawDescGZIP(), []int{7, 0, 0}
 }
 
-func (x *SignUpCallResponse_Result_Success) GetToken() string {
+func (x *SubscribeEventsCallResponse_Result_Success) GetEventId() uint64 {
 	if x != nil {
-		return x.Token
+		return x.EventId
+	}
+	return 0
+}
+
+func (x *SubscribeEventsCallResponse_Result_Success) GetTopic() string {
+	if x != nil {
+		return x.Topic
 	}
 	return ""
 }
 
+func (x *SubscribeEventsCallResponse_Result_Success) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubscribeEventsCallResponse_Result_Success) GetReset_() bool {
+	if x != nil {
+		return x.Reset_
+	}
+	return false
+}
+
 var File_calls_proto protoreflect.FileDescriptor
 
 var file_calls_proto_rawDesc = []byte{
@@ -652,90 +1232,178 @@ var file_calls_proto_rawDesc = []byte{
 	0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65,
 	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f,
-	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70,
-	0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
-	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x22, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d,
-	0x65, 0x74, 0x61, 0x12, 0x41, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e,
+	0x65, 0x6d, 0x70, 0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72,
+	0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x62, 0x75, 0x66, 0x2f, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61,
+	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a,
+	0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66,
+	0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74,
+	0x61, 0x12, 0x41, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61,
+	0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x3a, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64,
+	0x22, 0x9d, 0x02, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x42, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69,
+	0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
+	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xb2, 0x01, 0x0a, 0x06,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69,
+	0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e,
+	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61,
+	0x69, 0x6c, 0x75, 0x72, 0x65, 0x1a, 0x1f, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x65,
+	0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x41,
+	0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x3a, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22, 0x9d, 0x02,
+	0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x42, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72,
+	0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xb2, 0x01, 0x0a, 0x06, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72,
+	0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63,
+	0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75,
+	0x72, 0x65, 0x1a, 0x1f, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x5e, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x65, 0x74,
+	0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70,
+	0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x22, 0xc1, 0x02,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x45, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69,
+	0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xd0,
+	0x01, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x51, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x35, 0x2e, 0x67, 0x6f, 0x5f,
+	0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73,
+	0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x1a, 0x3a, 0x0a, 0x07, 0x53,
+	0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2f, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x06, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0x8d, 0x02, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65,
+	0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61, 0x12, 0x4a, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f,
+	0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x1a, 0x5b, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06,
+	0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x27, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x48, 0x00, 0x52,
+	0x0b, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x88, 0x01, 0x01, 0x42,
+	0x10, 0x0a, 0x0e, 0x5f, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x64, 0x22, 0x95, 0x03, 0x0a, 0x1b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x4b, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x33, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61,
+	0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0x98,
+	0x02, 0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x57, 0x0a, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x67, 0x6f, 0x5f,
+	0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e,
+	0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65,
+	0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x46,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72,
+	0x65, 0x1a, 0x7c, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x12, 0x2a, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x73,
+	0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x42,
+	0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32, 0x88, 0x04, 0x0a, 0x07, 0x4d, 0x61,
+	0x69, 0x6e, 0x41, 0x70, 0x69, 0x12, 0x72, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x12,
+	0x22, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c,
+	0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e,
 	0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06,
-	0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x1a, 0x3a, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73,
-	0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
-	0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f,
-	0x72, 0x64, 0x22, 0x9d, 0x02, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61, 0x6c,
-	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x42, 0x0a, 0x06, 0x72, 0x65, 0x73,
-	0x75, 0x6c, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6f, 0x5f, 0x62,
-	0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xb2, 0x01,
-	0x0a, 0x06, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63,
-	0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x5f, 0x62,
-	0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52,
-	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c,
-	0x75, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x66, 0x2e, 0x74,
-	0x79, 0x70, 0x65, 0x73, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07,
-	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x1a, 0x1f, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65,
-	0x73, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x22, 0xda, 0x01, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c,
-	0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x22, 0x0a, 0x04,
-	0x6d, 0x65, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x64, 0x66, 0x2e,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x52, 0x04, 0x6d, 0x65, 0x74, 0x61,
-	0x12, 0x41, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x29, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c,
-	0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72,
-	0x61, 0x6d, 0x73, 0x1a, 0x3a, 0x0a, 0x06, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d,
-	0x61, 0x69, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x22,
-	0x9d, 0x02, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x42, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c,
-	0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43,
-	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x1a, 0xb2, 0x01, 0x0a, 0x06, 0x52,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x4e, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c,
-	0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43,
-	0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x52, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x2e, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x48, 0x00, 0x52, 0x07, 0x73, 0x75,
-	0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x64, 0x66, 0x2e, 0x74, 0x79, 0x70, 0x65,
-	0x73, 0x2e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x48, 0x00, 0x52, 0x07, 0x66, 0x61, 0x69,
-	0x6c, 0x75, 0x72, 0x65, 0x1a, 0x1f, 0x0a, 0x07, 0x53, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12,
-	0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x42, 0x08, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x32,
-	0xf1, 0x01, 0x0a, 0x07, 0x4d, 0x61, 0x69, 0x6e, 0x41, 0x70, 0x69, 0x12, 0x72, 0x0a, 0x06, 0x53,
-	0x69, 0x67, 0x6e, 0x49, 0x6e, 0x12, 0x22, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65,
-	0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x6e, 0x43, 0x61,
-	0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x67, 0x6f, 0x5f, 0x62,
-	0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x49, 0x6e, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f,
-	0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f,
-	0x76, 0x31, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2d, 0x69, 0x6e, 0x12,
-	0x72, 0x0a, 0x06, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x12, 0x22, 0x2e, 0x67, 0x6f, 0x5f, 0x62,
-	0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e,
-	0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
-	0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e,
-	0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f,
-	0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x73, 0x69, 0x67, 0x6e,
-	0x2d, 0x75, 0x70, 0x42, 0x08, 0x5a, 0x06, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x19,
+	0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x75, 0x74,
+	0x68, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2d, 0x69, 0x6e, 0x12, 0x72, 0x0a, 0x06, 0x53, 0x69, 0x67,
+	0x6e, 0x55, 0x70, 0x12, 0x22, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e,
+	0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70, 0x43, 0x61, 0x6c, 0x6c,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69,
+	0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x55, 0x70,
+	0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1f, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x19, 0x3a, 0x01, 0x2a, 0x22, 0x14, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31,
+	0x2f, 0x61, 0x75, 0x74, 0x68, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x2d, 0x75, 0x70, 0x12, 0x7f, 0x0a,
+	0x09, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x25, 0x2e, 0x67, 0x6f, 0x5f,
+	0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x26, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61,
+	0x6c, 0x6c, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x43, 0x61, 0x6c,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82, 0xd3, 0xe4, 0x93, 0x02,
+	0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x2f, 0x67, 0x65, 0x74, 0x2d, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x93,
+	0x01, 0x0a, 0x0f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x2b, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63,
+	0x61, 0x6c, 0x6c, 0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2c, 0x2e, 0x67, 0x6f, 0x5f, 0x62, 0x6f, 0x69, 0x6c, 0x65, 0x72, 0x2e, 0x63, 0x61, 0x6c, 0x6c,
+	0x73, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x73, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x23, 0x82,
+	0xd3, 0xe4, 0x93, 0x02, 0x1d, 0x3a, 0x01, 0x2a, 0x22, 0x18, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76,
+	0x31, 0x2f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x30, 0x01, 0x42, 0x08, 0x5a, 0x06, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -750,41 +1418,67 @@ func file_calls_proto_rawDescGZIP() []byte {
 	return file_calls_proto_rawDescData
 }
 
-var file_calls_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_calls_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_calls_proto_goTypes = []interface{}{
-	(*SignInCallRequest)(nil),                 // 0: go_boiler.calls.SignInCallRequest
-	(*SignInCallResponse)(nil),                // 1: go_boiler.calls.SignInCallResponse
-	(*SignUpCallRequest)(nil),                 // 2: go_boiler.calls.SignUpCallRequest
-	(*SignUpCallResponse)(nil),                // 3: go_boiler.calls.SignUpCallResponse
-	(*SignInCallRequest_Params)(nil),          // 4: go_boiler.calls.SignInCallRequest.Params
-	(*SignInCallResponse_Result)(nil),         // 5: go_boiler.calls.SignInCallResponse.Result
-	(*SignInCallResponse_Result_Success)(nil), // 6: go_boiler.calls.SignInCallResponse.Result.Success
-	(*SignUpCallRequest_Params)(nil),          // 7: go_boiler.calls.SignUpCallRequest.Params
-	(*SignUpCallResponse_Result)(nil),         // 8: go_boiler.calls.SignUpCallResponse.Result
-	(*SignUpCallResponse_Result_Success)(nil), // 9: go_boiler.calls.SignUpCallResponse.Result.Success
-	(*Meta)(nil),    // 10: df.types.Meta
-	(*Failure)(nil), // 11: df.types.Failure
+	(*SignInCallRequest)(nil),                          // 0: go_boiler.calls.SignInCallRequest
+	(*SignInCallResponse)(nil),                         // 1: go_boiler.calls.SignInCallResponse
+	(*SignUpCallRequest)(nil),                          // 2: go_boiler.calls.SignUpCallRequest
+	(*SignUpCallResponse)(nil),                         // 3: go_boiler.calls.SignUpCallResponse
+	(*GetConfigCallRequest)(nil),                       // 4: go_boiler.calls.GetConfigCallRequest
+	(*GetConfigCallResponse)(nil),                      // 5: go_boiler.calls.GetConfigCallResponse
+	(*SubscribeEventsCallRequest)(nil),                 // 6: go_boiler.calls.SubscribeEventsCallRequest
+	(*SubscribeEventsCallResponse)(nil),                // 7: go_boiler.calls.SubscribeEventsCallResponse
+	(*SignInCallRequest_Params)(nil),                   // 8: go_boiler.calls.SignInCallRequest.Params
+	(*SignInCallResponse_Result)(nil),                  // 9: go_boiler.calls.SignInCallResponse.Result
+	(*SignInCallResponse_Result_Success)(nil),          // 10: go_boiler.calls.SignInCallResponse.Result.Success
+	(*SignUpCallRequest_Params)(nil),                   // 11: go_boiler.calls.SignUpCallRequest.Params
+	(*SignUpCallResponse_Result)(nil),                  // 12: go_boiler.calls.SignUpCallResponse.Result
+	(*SignUpCallResponse_Result_Success)(nil),          // 13: go_boiler.calls.SignUpCallResponse.Result.Success
+	(*GetConfigCallResponse_Result)(nil),               // 14: go_boiler.calls.GetConfigCallResponse.Result
+	(*GetConfigCallResponse_Result_Success)(nil),       // 15: go_boiler.calls.GetConfigCallResponse.Result.Success
+	(*SubscribeEventsCallRequest_Params)(nil),          // 16: go_boiler.calls.SubscribeEventsCallRequest.Params
+	(*SubscribeEventsCallResponse_Result)(nil),         // 17: go_boiler.calls.SubscribeEventsCallResponse.Result
+	(*SubscribeEventsCallResponse_Result_Success)(nil), // 18: go_boiler.calls.SubscribeEventsCallResponse.Result.Success
+	(*Meta)(nil),            // 19: df.types.Meta
+	(*Failure)(nil),         // 20: df.types.Failure
+	(*structpb.Struct)(nil), // 21: google.protobuf.Struct
+	(*structpb.Value)(nil),  // 22: google.protobuf.Value
 }
 var file_calls_proto_depIdxs = []int32{
-	10, // 0: go_boiler.calls.SignInCallRequest.meta:type_name -> df.types.Meta
-	4,  // 1: go_boiler.calls.SignInCallRequest.params:type_name -> go_boiler.calls.SignInCallRequest.Params
-	5,  // 2: go_boiler.calls.SignInCallResponse.result:type_name -> go_boiler.calls.SignInCallResponse.Result
-	10, // 3: go_boiler.calls.SignUpCallRequest.meta:type_name -> df.types.Meta
-	7,  // 4: go_boiler.calls.SignUpCallRequest.params:type_name -> go_boiler.calls.SignUpCallRequest.Params
-	8,  // 5: go_boiler.calls.SignUpCallResponse.result:type_name -> go_boiler.calls.SignUpCallResponse.Result
-	6,  // 6: go_boiler.calls.SignInCallResponse.Result.success:type_name -> go_boiler.calls.SignInCallResponse.Result.Success
-	11, // 7: go_boiler.calls.SignInCallResponse.Result.failure:type_name -> df.types.Failure
-	9,  // 8: go_boiler.calls.SignUpCallResponse.Result.success:type_name -> go_boiler.calls.SignUpCallResponse.Result.Success
-	11, // 9: go_boiler.calls.SignUpCallResponse.Result.failure:type_name -> df.types.Failure
-	0,  // 10: go_boiler.calls.MainApi.SignIn:input_type -> go_boiler.calls.SignInCallRequest
-	2,  // 11: go_boiler.calls.MainApi.SignUp:input_type -> go_boiler.calls.SignUpCallRequest
-	1,  // 12: go_boiler.calls.MainApi.SignIn:output_type -> go_boiler.calls.SignInCallResponse
-	3,  // 13: go_boiler.calls.MainApi.SignUp:output_type -> go_boiler.calls.SignUpCallResponse
-	12, // [12:14] is the sub-list for method output_type
-	10, // [10:12] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	19, // 0: go_boiler.calls.SignInCallRequest.meta:type_name -> df.types.Meta
+	8,  // 1: go_boiler.calls.SignInCallRequest.params:type_name -> go_boiler.calls.SignInCallRequest.Params
+	9,  // 2: go_boiler.calls.SignInCallResponse.result:type_name -> go_boiler.calls.SignInCallResponse.Result
+	19, // 3: go_boiler.calls.SignUpCallRequest.meta:type_name -> df.types.Meta
+	11, // 4: go_boiler.calls.SignUpCallRequest.params:type_name -> go_boiler.calls.SignUpCallRequest.Params
+	12, // 5: go_boiler.calls.SignUpCallResponse.result:type_name -> go_boiler.calls.SignUpCallResponse.Result
+	19, // 6: go_boiler.calls.GetConfigCallRequest.meta:type_name -> df.types.Meta
+	14, // 7: go_boiler.calls.GetConfigCallResponse.result:type_name -> go_boiler.calls.GetConfigCallResponse.Result
+	19, // 8: go_boiler.calls.SubscribeEventsCallRequest.meta:type_name -> df.types.Meta
+	16, // 9: go_boiler.calls.SubscribeEventsCallRequest.params:type_name -> go_boiler.calls.SubscribeEventsCallRequest.Params
+	17, // 10: go_boiler.calls.SubscribeEventsCallResponse.result:type_name -> go_boiler.calls.SubscribeEventsCallResponse.Result
+	10, // 11: go_boiler.calls.SignInCallResponse.Result.success:type_name -> go_boiler.calls.SignInCallResponse.Result.Success
+	20, // 12: go_boiler.calls.SignInCallResponse.Result.failure:type_name -> df.types.Failure
+	13, // 13: go_boiler.calls.SignUpCallResponse.Result.success:type_name -> go_boiler.calls.SignUpCallResponse.Result.Success
+	20, // 14: go_boiler.calls.SignUpCallResponse.Result.failure:type_name -> df.types.Failure
+	15, // 15: go_boiler.calls.GetConfigCallResponse.Result.success:type_name -> go_boiler.calls.GetConfigCallResponse.Result.Success
+	20, // 16: go_boiler.calls.GetConfigCallResponse.Result.failure:type_name -> df.types.Failure
+	21, // 17: go_boiler.calls.GetConfigCallResponse.Result.Success.config:type_name -> google.protobuf.Struct
+	18, // 18: go_boiler.calls.SubscribeEventsCallResponse.Result.success:type_name -> go_boiler.calls.SubscribeEventsCallResponse.Result.Success
+	20, // 19: go_boiler.calls.SubscribeEventsCallResponse.Result.failure:type_name -> df.types.Failure
+	22, // 20: go_boiler.calls.SubscribeEventsCallResponse.Result.Success.data:type_name -> google.protobuf.Value
+	0,  // 21: go_boiler.calls.MainApi.SignIn:input_type -> go_boiler.calls.SignInCallRequest
+	2,  // 22: go_boiler.calls.MainApi.SignUp:input_type -> go_boiler.calls.SignUpCallRequest
+	4,  // 23: go_boiler.calls.MainApi.GetConfig:input_type -> go_boiler.calls.GetConfigCallRequest
+	6,  // 24: go_boiler.calls.MainApi.SubscribeEvents:input_type -> go_boiler.calls.SubscribeEventsCallRequest
+	1,  // 25: go_boiler.calls.MainApi.SignIn:output_type -> go_boiler.calls.SignInCallResponse
+	3,  // 26: go_boiler.calls.MainApi.SignUp:output_type -> go_boiler.calls.SignUpCallResponse
+	5,  // 27: go_boiler.calls.MainApi.GetConfig:output_type -> go_boiler.calls.GetConfigCallResponse
+	7,  // 28: go_boiler.calls.MainApi.SubscribeEvents:output_type -> go_boiler.calls.SubscribeEventsCallResponse
+	25, // [25:29] is the sub-list for method output_type
+	21, // [21:25] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_calls_proto_init() }
@@ -843,7 +1537,7 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInCallRequest_Params); i {
+			switch v := v.(*GetConfigCallRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -855,7 +1549,7 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInCallResponse_Result); i {
+			switch v := v.(*GetConfigCallResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -867,7 +1561,7 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignInCallResponse_Result_Success); i {
+			switch v := v.(*SubscribeEventsCallRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -879,7 +1573,7 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignUpCallRequest_Params); i {
+			switch v := v.(*SubscribeEventsCallResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -891,7 +1585,7 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignUpCallResponse_Result); i {
+			switch v := v.(*SignInCallRequest_Params); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -903,6 +1597,54 @@ func file_calls_proto_init() {
 			}
 		}
 		file_calls_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInCallResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignInCallResponse_Result_Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignUpCallRequest_Params); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignUpCallResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SignUpCallResponse_Result_Success); i {
 			case 0:
 				return &v.state
@@ -914,22 +1656,91 @@ func file_calls_proto_init() {
 				return nil
 			}
 		}
+		file_calls_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigCallResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetConfigCallResponse_Result_Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsCallRequest_Params); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsCallResponse_Result); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_calls_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeEventsCallResponse_Result_Success); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_calls_proto_msgTypes[5].OneofWrappers = []interface{}{
+	file_calls_proto_msgTypes[9].OneofWrappers = []interface{}{
 		(*SignInCallResponse_Result_Success_)(nil),
 		(*SignInCallResponse_Result_Failure)(nil),
 	}
-	file_calls_proto_msgTypes[8].OneofWrappers = []interface{}{
+	file_calls_proto_msgTypes[12].OneofWrappers = []interface{}{
 		(*SignUpCallResponse_Result_Success_)(nil),
 		(*SignUpCallResponse_Result_Failure)(nil),
 	}
+	file_calls_proto_msgTypes[14].OneofWrappers = []interface{}{
+		(*GetConfigCallResponse_Result_Success_)(nil),
+		(*GetConfigCallResponse_Result_Failure)(nil),
+	}
+	file_calls_proto_msgTypes[16].OneofWrappers = []interface{}{}
+	file_calls_proto_msgTypes[17].OneofWrappers = []interface{}{
+		(*SubscribeEventsCallResponse_Result_Success_)(nil),
+		(*SubscribeEventsCallResponse_Result_Failure)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_calls_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	MainApi_SignIn_FullMethodName = "/go_boiler.calls.MainApi/SignIn"
-	MainApi_SignUp_FullMethodName = "/go_boiler.calls.MainApi/SignUp"
+	MainApi_SignIn_FullMethodName          = "/go_boiler.calls.MainApi/SignIn"
+	MainApi_SignUp_FullMethodName          = "/go_boiler.calls.MainApi/SignUp"
+	MainApi_GetConfig_FullMethodName       = "/go_boiler.calls.MainApi/GetConfig"
+	MainApi_SubscribeEvents_FullMethodName = "/go_boiler.calls.MainApi/SubscribeEvents"
 )
 
 // MainApiClient is the client API for MainApi service.
@@ -29,6 +31,8 @@ const (
 type MainApiClient interface {
 	SignIn(ctx context.Context, in *SignInCallRequest, opts ...grpc.CallOption) (*SignInCallResponse, error)
 	SignUp(ctx context.Context, in *SignUpCallRequest, opts ...grpc.CallOption) (*SignUpCallResponse, error)
+	GetConfig(ctx context.Context, in *GetConfigCallRequest, opts ...grpc.CallOption) (*GetConfigCallResponse, error)
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsCallRequest, opts ...grpc.CallOption) (MainApi_SubscribeEventsClient, error)
 }
 
 type mainApiClient struct {
@@ -57,12 +61,55 @@ func (c *mainApiClient) SignUp(ctx context.Context, in *SignUpCallRequest, opts
 	return out, nil
 }
 
+func (c *mainApiClient) GetConfig(ctx context.Context, in *GetConfigCallRequest, opts ...grpc.CallOption) (*GetConfigCallResponse, error) {
+	out := new(GetConfigCallResponse)
+	err := c.cc.Invoke(ctx, MainApi_GetConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mainApiClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsCallRequest, opts ...grpc.CallOption) (MainApi_SubscribeEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MainApi_ServiceDesc.Streams[0], MainApi_SubscribeEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mainApiSubscribeEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MainApi_SubscribeEventsClient interface {
+	Recv() (*SubscribeEventsCallResponse, error)
+	grpc.ClientStream
+}
+
+type mainApiSubscribeEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mainApiSubscribeEventsClient) Recv() (*SubscribeEventsCallResponse, error) {
+	m := new(SubscribeEventsCallResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // MainApiServer is the server API for MainApi service.
 // All implementations must embed UnimplementedMainApiServer
 // for forward compatibility
 type MainApiServer interface {
 	SignIn(context.Context, *SignInCallRequest) (*SignInCallResponse, error)
 	SignUp(context.Context, *SignUpCallRequest) (*SignUpCallResponse, error)
+	GetConfig(context.Context, *GetConfigCallRequest) (*GetConfigCallResponse, error)
+	SubscribeEvents(*SubscribeEventsCallRequest, MainApi_SubscribeEventsServer) error
 	mustEmbedUnimplementedMainApiServer()
 }
 
@@ -76,6 +123,12 @@ func (UnimplementedMainApiServer) SignIn(context.Context, *SignInCallRequest) (*
 func (UnimplementedMainApiServer) SignUp(context.Context, *SignUpCallRequest) (*SignUpCallResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignUp not implemented")
 }
+func (UnimplementedMainApiServer) GetConfig(context.Context, *GetConfigCallRequest) (*GetConfigCallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConfig not implemented")
+}
+func (UnimplementedMainApiServer) SubscribeEvents(*SubscribeEventsCallRequest, MainApi_SubscribeEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
 func (UnimplementedMainApiServer) mustEmbedUnimplementedMainApiServer() {}
 
 // UnsafeMainApiServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +178,45 @@ func _MainApi_SignUp_Handler(srv interface{}, ctx context.Context, dec func(inte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MainApi_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigCallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MainApiServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MainApi_GetConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MainApiServer).GetConfig(ctx, req.(*GetConfigCallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MainApi_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsCallRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MainApiServer).SubscribeEvents(m, &mainApiSubscribeEventsServer{stream})
+}
+
+type MainApi_SubscribeEventsServer interface {
+	Send(*SubscribeEventsCallResponse) error
+	grpc.ServerStream
+}
+
+type mainApiSubscribeEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mainApiSubscribeEventsServer) Send(m *SubscribeEventsCallResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // MainApi_ServiceDesc is the grpc.ServiceDesc for MainApi service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,7 +232,17 @@ var MainApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignUp",
 			Handler:    _MainApi_SignUp_Handler,
 		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _MainApi_GetConfig_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _MainApi_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "calls.proto",
 }
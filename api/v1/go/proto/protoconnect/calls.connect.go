@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: calls.proto
+
+package protoconnect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	proto "github.com/Dionid/go-boiler/api/v1/go/proto"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// MainApiName is the fully-qualified name of the MainApi service.
+	MainApiName = "go_boiler.calls.MainApi"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// MainApiSignInProcedure is the fully-qualified name of the MainApi's SignIn RPC.
+	MainApiSignInProcedure = "/go_boiler.calls.MainApi/SignIn"
+	// MainApiSignUpProcedure is the fully-qualified name of the MainApi's SignUp RPC.
+	MainApiSignUpProcedure = "/go_boiler.calls.MainApi/SignUp"
+	// MainApiGetConfigProcedure is the fully-qualified name of the MainApi's GetConfig RPC.
+	MainApiGetConfigProcedure = "/go_boiler.calls.MainApi/GetConfig"
+	// MainApiSubscribeEventsProcedure is the fully-qualified name of the MainApi's SubscribeEvents RPC.
+	MainApiSubscribeEventsProcedure = "/go_boiler.calls.MainApi/SubscribeEvents"
+)
+
+// MainApiClient is a client for the go_boiler.calls.MainApi service.
+type MainApiClient interface {
+	SignIn(context.Context, *connect.Request[proto.SignInCallRequest]) (*connect.Response[proto.SignInCallResponse], error)
+	SignUp(context.Context, *connect.Request[proto.SignUpCallRequest]) (*connect.Response[proto.SignUpCallResponse], error)
+	GetConfig(context.Context, *connect.Request[proto.GetConfigCallRequest]) (*connect.Response[proto.GetConfigCallResponse], error)
+	SubscribeEvents(context.Context, *connect.Request[proto.SubscribeEventsCallRequest]) (*connect.ServerStreamForClient[proto.SubscribeEventsCallResponse], error)
+}
+
+// NewMainApiClient constructs a client for the go_boiler.calls.MainApi service. By default, it uses
+// the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewMainApiClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) MainApiClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	mainApiMethods := proto.File_calls_proto.Services().ByName("MainApi").Methods()
+	return &mainApiClient{
+		signIn: connect.NewClient[proto.SignInCallRequest, proto.SignInCallResponse](
+			httpClient,
+			baseURL+MainApiSignInProcedure,
+			connect.WithSchema(mainApiMethods.ByName("SignIn")),
+			connect.WithClientOptions(opts...),
+		),
+		signUp: connect.NewClient[proto.SignUpCallRequest, proto.SignUpCallResponse](
+			httpClient,
+			baseURL+MainApiSignUpProcedure,
+			connect.WithSchema(mainApiMethods.ByName("SignUp")),
+			connect.WithClientOptions(opts...),
+		),
+		getConfig: connect.NewClient[proto.GetConfigCallRequest, proto.GetConfigCallResponse](
+			httpClient,
+			baseURL+MainApiGetConfigProcedure,
+			connect.WithSchema(mainApiMethods.ByName("GetConfig")),
+			connect.WithClientOptions(opts...),
+		),
+		subscribeEvents: connect.NewClient[proto.SubscribeEventsCallRequest, proto.SubscribeEventsCallResponse](
+			httpClient,
+			baseURL+MainApiSubscribeEventsProcedure,
+			connect.WithSchema(mainApiMethods.ByName("SubscribeEvents")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// mainApiClient implements MainApiClient.
+type mainApiClient struct {
+	signIn          *connect.Client[proto.SignInCallRequest, proto.SignInCallResponse]
+	signUp          *connect.Client[proto.SignUpCallRequest, proto.SignUpCallResponse]
+	getConfig       *connect.Client[proto.GetConfigCallRequest, proto.GetConfigCallResponse]
+	subscribeEvents *connect.Client[proto.SubscribeEventsCallRequest, proto.SubscribeEventsCallResponse]
+}
+
+// SignIn calls go_boiler.calls.MainApi.SignIn.
+func (c *mainApiClient) SignIn(ctx context.Context, req *connect.Request[proto.SignInCallRequest]) (*connect.Response[proto.SignInCallResponse], error) {
+	return c.signIn.CallUnary(ctx, req)
+}
+
+// SignUp calls go_boiler.calls.MainApi.SignUp.
+func (c *mainApiClient) SignUp(ctx context.Context, req *connect.Request[proto.SignUpCallRequest]) (*connect.Response[proto.SignUpCallResponse], error) {
+	return c.signUp.CallUnary(ctx, req)
+}
+
+// GetConfig calls go_boiler.calls.MainApi.GetConfig.
+func (c *mainApiClient) GetConfig(ctx context.Context, req *connect.Request[proto.GetConfigCallRequest]) (*connect.Response[proto.GetConfigCallResponse], error) {
+	return c.getConfig.CallUnary(ctx, req)
+}
+
+// SubscribeEvents calls go_boiler.calls.MainApi.SubscribeEvents.
+func (c *mainApiClient) SubscribeEvents(ctx context.Context, req *connect.Request[proto.SubscribeEventsCallRequest]) (*connect.ServerStreamForClient[proto.SubscribeEventsCallResponse], error) {
+	return c.subscribeEvents.CallServerStream(ctx, req)
+}
+
+// MainApiHandler is an implementation of the go_boiler.calls.MainApi service.
+type MainApiHandler interface {
+	SignIn(context.Context, *connect.Request[proto.SignInCallRequest]) (*connect.Response[proto.SignInCallResponse], error)
+	SignUp(context.Context, *connect.Request[proto.SignUpCallRequest]) (*connect.Response[proto.SignUpCallResponse], error)
+	GetConfig(context.Context, *connect.Request[proto.GetConfigCallRequest]) (*connect.Response[proto.GetConfigCallResponse], error)
+	SubscribeEvents(context.Context, *connect.Request[proto.SubscribeEventsCallRequest], *connect.ServerStream[proto.SubscribeEventsCallResponse]) error
+}
+
+// NewMainApiHandler builds an HTTP handler from the service implementation. It returns the path on
+// which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewMainApiHandler(svc MainApiHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	mainApiMethods := proto.File_calls_proto.Services().ByName("MainApi").Methods()
+	mainApiSignInHandler := connect.NewUnaryHandler(
+		MainApiSignInProcedure,
+		svc.SignIn,
+		connect.WithSchema(mainApiMethods.ByName("SignIn")),
+		connect.WithHandlerOptions(opts...),
+	)
+	mainApiSignUpHandler := connect.NewUnaryHandler(
+		MainApiSignUpProcedure,
+		svc.SignUp,
+		connect.WithSchema(mainApiMethods.ByName("SignUp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	mainApiGetConfigHandler := connect.NewUnaryHandler(
+		MainApiGetConfigProcedure,
+		svc.GetConfig,
+		connect.WithSchema(mainApiMethods.ByName("GetConfig")),
+		connect.WithHandlerOptions(opts...),
+	)
+	mainApiSubscribeEventsHandler := connect.NewServerStreamHandler(
+		MainApiSubscribeEventsProcedure,
+		svc.SubscribeEvents,
+		connect.WithSchema(mainApiMethods.ByName("SubscribeEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/go_boiler.calls.MainApi/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case MainApiSignInProcedure:
+			mainApiSignInHandler.ServeHTTP(w, r)
+		case MainApiSignUpProcedure:
+			mainApiSignUpHandler.ServeHTTP(w, r)
+		case MainApiGetConfigProcedure:
+			mainApiGetConfigHandler.ServeHTTP(w, r)
+		case MainApiSubscribeEventsProcedure:
+			mainApiSubscribeEventsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedMainApiHandler returns CodeUnimplemented from all methods.
+type UnimplementedMainApiHandler struct{}
+
+func (UnimplementedMainApiHandler) SignIn(context.Context, *connect.Request[proto.SignInCallRequest]) (*connect.Response[proto.SignInCallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("go_boiler.calls.MainApi.SignIn is not implemented"))
+}
+
+func (UnimplementedMainApiHandler) SignUp(context.Context, *connect.Request[proto.SignUpCallRequest]) (*connect.Response[proto.SignUpCallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("go_boiler.calls.MainApi.SignUp is not implemented"))
+}
+
+func (UnimplementedMainApiHandler) GetConfig(context.Context, *connect.Request[proto.GetConfigCallRequest]) (*connect.Response[proto.GetConfigCallResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("go_boiler.calls.MainApi.GetConfig is not implemented"))
+}
+
+func (UnimplementedMainApiHandler) SubscribeEvents(context.Context, *connect.Request[proto.SubscribeEventsCallRequest], *connect.ServerStream[proto.SubscribeEventsCallResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("go_boiler.calls.MainApi.SubscribeEvents is not implemented"))
+}
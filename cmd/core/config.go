@@ -1,58 +1,237 @@
 package main
 
 import (
-	"strings"
-
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Dionid/go-boiler/dbs/maindb/migrations"
+	pkgconfig "github.com/Dionid/go-boiler/pkg/config"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
 )
 
 type Config struct {
+	// ConfigFile is YAML file path from --config or CONFIG_FILE
+	ConfigFile string `mapstructure:"-"`
+	// MigrationsDir is where `migrate create` writes, from --migrations-dir
+	MigrationsDir string `mapstructure:"-"`
+
 	Env string `mapstructure:"ENV"`
 
-	Host  string `mapstructure:"HOST"`
-	Port  int    `mapstructure:"PORT"`
+	Host  string `mapstructure:"HOST" validate:"required"`
+	Port  int    `mapstructure:"PORT" validate:"min=1,max=65535"`
 	Https bool   `mapstructure:"HTTPS"`
 
-	MainDbConnectionStr string `mapstructure:"MAIN_DB_CONNECTION_STR"`
+	// # TLS is terminated on PORT when cert and key are set
+	TlsCertFile     string `mapstructure:"TLS_CERT_FILE" validate:"required_with=TlsKeyFile,omitempty,file"`
+	TlsKeyFile      string `mapstructure:"TLS_KEY_FILE" validate:"required_with=TlsCertFile,omitempty,file"`
+	TlsClientCaFile string `mapstructure:"TLS_CLIENT_CA_FILE" validate:"required_if=TlsClientAuth verify_if_given,required_if=TlsClientAuth require_and_verify,omitempty,file"`
+	TlsClientAuth   string `mapstructure:"TLS_CLIENT_AUTH" validate:"oneof=none request require verify_if_given require_and_verify"`
+
+	MainDbConnectionStr string `mapstructure:"MAIN_DB_CONNECTION_STR" validate:"required,postgres_dsn" secret:"url"`
+	// MainDbPassword replaces password in MainDbConnectionStr
+	MainDbPassword string `mapstructure:"MAIN_DB_PASSWORD" secret:"true"`
+	// MigrateOnStart applies embedded migrations before serving, replicas take turns by advisory lock
+	MigrateOnStart bool `mapstructure:"MIGRATE_ON_START"`
 
-	FirstUserEmail    string `mapstructure:"FIRST_USER_EMAIL"`
-	FirstUserPassword string `mapstructure:"FIRST_USER_PASSWORD"`
+	FirstUserEmail    string `mapstructure:"FIRST_USER_EMAIL" validate:"required,email"`
+	FirstUserPassword string `mapstructure:"FIRST_USER_PASSWORD" validate:"required" secret:"true"`
 
 	SwaggerPathPrefix string `mapstructure:"SWAGGER_PATH_PREFIX"`
 
-	JwtSecret          string `mapstructure:"JWT_SECRET"`
-	JwtExpireInSeconds int64  `mapstructure:"JWT_EXPIRE_IN_SECONDS"`
+	// GatewayTransport is how gateway reaches gRPC server
+	GatewayTransport string `mapstructure:"GATEWAY_TRANSPORT" validate:"oneof=in-process loopback"`
+
+	JwtSecret          string `mapstructure:"JWT_SECRET" validate:"required" secret:"true"`
+	JwtExpireInSeconds int64  `mapstructure:"JWT_EXPIRE_IN_SECONDS" validate:"gt=0"`
+
+	FailurePolicy string `mapstructure:"FAILURE_POLICY" validate:"oneof=transport public"`
+
+	CaptureErrorStack bool `mapstructure:"CAPTURE_ERROR_STACK"`
+
+	DefaultLocale string `mapstructure:"DEFAULT_LOCALE" validate:"required,bcp47_language_tag"`
+
+	MetricsNamespace string `mapstructure:"METRICS_NAMESPACE"`
+	MetricsPort      int    `mapstructure:"METRICS_PORT" validate:"min=0,max=65535"`
+
+	TracingServiceName  string  `mapstructure:"TRACING_SERVICE_NAME" validate:"required"`
+	TracingExporter     string  `mapstructure:"TRACING_EXPORTER" validate:"oneof=none otlp stdout file"`
+	TracingOtlpEndpoint string  `mapstructure:"TRACING_OTLP_ENDPOINT" validate:"required_if=TracingExporter otlp"`
+	TracingOtlpInsecure bool    `mapstructure:"TRACING_OTLP_INSECURE"`
+	TracingFilePath     string  `mapstructure:"TRACING_FILE_PATH" validate:"required_if=TracingExporter file"`
+	TracingSampleRatio  float64 `mapstructure:"TRACING_SAMPLE_RATIO" validate:"gte=0,lte=1"`
+
+	// EventsReplaySize is how many last events are kept to resume subscriptions
+	EventsReplaySize int `mapstructure:"EVENTS_REPLAY_SIZE" validate:"gte=0"`
+	// EventsHeartbeatInSeconds keeps SSE connections open through proxies
+	EventsHeartbeatInSeconds int64 `mapstructure:"EVENTS_HEARTBEAT_IN_SECONDS" validate:"gt=0"`
+
+	// # WebSocket calls and server push
+	WsIdleTimeoutInSeconds int64 `mapstructure:"WS_IDLE_TIMEOUT_IN_SECONDS" validate:"gt=0"`
+	WsMaxInFlight          int   `mapstructure:"WS_MAX_IN_FLIGHT" validate:"gt=0"`
+	WsOutboxSize           int   `mapstructure:"WS_OUTBOX_SIZE" validate:"gt=0"`
+	WsMaxFrameBytes        int   `mapstructure:"WS_MAX_FRAME_BYTES" validate:"gt=0"`
+
+	// IdempotencyTtlInSeconds is how long response is replayed for the same idempotency key
+	IdempotencyTtlInSeconds int64 `mapstructure:"IDEMPOTENCY_TTL_IN_SECONDS" validate:"gt=0"`
+	// IdempotencyCleanupIntervalInSeconds is how often expired idempotency keys are deleted
+	IdempotencyCleanupIntervalInSeconds int64 `mapstructure:"IDEMPOTENCY_CLEANUP_INTERVAL_IN_SECONDS" validate:"gt=0"`
+
+	// RateLimitStore is where limits state is kept: memory of the replica or shared postgres table
+	RateLimitStore string `mapstructure:"RATE_LIMIT_STORE" validate:"oneof=memory postgres"`
+	// TrustedProxies are CIDRs of proxies skipped from the right of `X-Forwarded-For` to find client IP,
+	// in-process bridges are always trusted
+	TrustedProxies []string `mapstructure:"TRUSTED_PROXIES" validate:"dive,cidr"`
+
+	// # Gateway JSON marshaling
+	GatewayJsonEmitDefaults  bool `mapstructure:"GATEWAY_JSON_EMIT_DEFAULTS"`
+	GatewayJsonUseProtoNames bool `mapstructure:"GATEWAY_JSON_USE_PROTO_NAMES"`
+
+	// CompressionMinBytes is response size from which gzip / zstd is applied
+	CompressionMinBytes int `mapstructure:"COMPRESSION_MIN_BYTES" validate:"gte=0"`
+	// CompressionMaxRequestBytes limits decompressed request body
+	CompressionMaxRequestBytes int64 `mapstructure:"COMPRESSION_MAX_REQUEST_BYTES" validate:"gt=0"`
+
+	HealthCheckTimeoutInSeconds int64 `mapstructure:"HEALTH_CHECK_TIMEOUT_IN_SECONDS" validate:"gt=0"`
+
+	ShutdownTimeoutInSeconds        int64 `mapstructure:"SHUTDOWN_TIMEOUT_IN_SECONDS" validate:"gt=0"`
+	ShutdownReadinessDelayInSeconds int64 `mapstructure:"SHUTDOWN_READINESS_DELAY_IN_SECONDS" validate:"gte=0,ltfield=ShutdownTimeoutInSeconds"`
+
+	VaultAddress                    string `mapstructure:"VAULT_ADDRESS" validate:"omitempty,url"`
+	VaultToken                      string `mapstructure:"VAULT_TOKEN" validate:"required_with=VaultAddress" secret:"true"`
+	SecretsRefreshIntervalInSeconds int64  `mapstructure:"SECRETS_REFRESH_INTERVAL_IN_SECONDS" validate:"gte=0"`
+
+	// # Reloadable without restart, see reloadableKeys
+
+	LogLevel         string   `mapstructure:"LOG_LEVEL" validate:"omitempty,oneof=debug info warn error"`
+	CorsAllowOrigins []string `mapstructure:"CORS_ALLOW_ORIGINS" validate:"dive,required"`
+	RateLimitRules   []string `mapstructure:"RATE_LIMIT_RULES"`
+	FeatureFlags     []string `mapstructure:"FEATURE_FLAGS" validate:"dive,required"`
 }
 
-// Call to load the variables from env
-func initConfig() (*Config, error) {
-	// # Read os env
-	viper.AutomaticEnv()
+func setConfigDefaults(v *viper.Viper) {
+	v.SetDefault("HOST", "localhost")
+	v.SetDefault("PORT", 8080)
+	v.SetDefault("TLS_CLIENT_AUTH", "none")
+	v.SetDefault("GATEWAY_TRANSPORT", "in-process")
+	v.SetDefault("FAILURE_POLICY", "transport")
+	v.SetDefault("DEFAULT_LOCALE", "en")
+	v.SetDefault("METRICS_NAMESPACE", "go_boiler")
+	v.SetDefault("TRACING_SERVICE_NAME", "go-boiler")
+	v.SetDefault("TRACING_EXPORTER", "none")
+	v.SetDefault("TRACING_FILE_PATH", "traces.json")
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1)
+	v.SetDefault("EVENTS_REPLAY_SIZE", 1000)
+	v.SetDefault("EVENTS_HEARTBEAT_IN_SECONDS", 15)
+	v.SetDefault("WS_IDLE_TIMEOUT_IN_SECONDS", 60)
+	v.SetDefault("WS_MAX_IN_FLIGHT", 16)
+	v.SetDefault("WS_OUTBOX_SIZE", 64)
+	v.SetDefault("WS_MAX_FRAME_BYTES", 1<<20)
+	v.SetDefault("IDEMPOTENCY_TTL_IN_SECONDS", 86400)
+	v.SetDefault("IDEMPOTENCY_CLEANUP_INTERVAL_IN_SECONDS", 3600)
+	v.SetDefault("RATE_LIMIT_STORE", "memory")
+	v.SetDefault("GATEWAY_JSON_EMIT_DEFAULTS", true)
+	v.SetDefault("GATEWAY_JSON_USE_PROTO_NAMES", false)
+	v.SetDefault("COMPRESSION_MIN_BYTES", 1024)
+	v.SetDefault("COMPRESSION_MAX_REQUEST_BYTES", 10<<20)
+	v.SetDefault("HEALTH_CHECK_TIMEOUT_IN_SECONDS", 2)
+	v.SetDefault("SHUTDOWN_TIMEOUT_IN_SECONDS", 30)
+	v.SetDefault("SECRETS_REFRESH_INTERVAL_IN_SECONDS", 60)
+	v.SetDefault("CORS_ALLOW_ORIGINS", []string{"*"})
+}
 
-	// # Tell viper the path/location of your env file. If it is root just add "."
-	viper.AddConfigPath(".")
+// initConfig loads config from (each next overrides previous):
+// defaults < app.env < YAML file (--config or CONFIG_FILE) < environment variables < CLI flags.
+// Returns positional args left after flags (subcommand).
+func initConfig(args []string) (*Config, []string, error) {
+	config := &Config{}
 
-	viper.SetDefault("PORT", 8080)
+	flags := pflag.NewFlagSet("core", pflag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: core [flags] [config print | config validate | migrate up | down | status | redo | create <name>]\nmigrate create writes to --migrations-dir, by default relative to repository root\n\nFlags:\n%s", flags.FlagUsages())
+	}
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "YAML config file")
+	migrationsDir := flags.String("migrations-dir", migrations.Dir, "directory of new migrations for `migrate create`, relative path is resolved from working directory")
+	pkgconfig.RegisterFlags(flags, config)
 
-	// # Tell viper the name of your file
-	viper.SetConfigName("app")
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
 
-	// # Tell viper the type of your file
-	viper.SetConfigType("env")
+	v := viper.New()
+	setConfigDefaults(v)
+
+	err := pkgconfig.Load(v, config, pkgconfig.Options{
+		EnvFileName:  "app",
+		EnvFilePaths: []string{"."},
+		YamlFile:     *configFile,
+		Flags:        flags,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// # Viper reads all the variables from env file and log error if any found
-	if err := viper.ReadInConfig(); err != nil {
-		if !strings.Contains(strings.ToLower(err.Error()), strings.ToLower("Not Found in")) {
-			return nil, err
-		}
+	config.ConfigFile = *configFile
+	config.MigrationsDir = *migrationsDir
+
+	return config, flags.Args(), nil
+}
+
+func (c *Config) Validate() error {
+	if err := pkgconfig.Validate(c); err != nil {
+		return err
 	}
 
-	config := &Config{}
+	if _, err := ratelimit.ParseRules(c.RateLimitRules); err != nil {
+		return fmt.Errorf("RATE_LIMIT_RULES: %w", err)
+	}
+
+	return nil
+}
+
+// EffectiveLogLevel is LOG_LEVEL or default of ENV: info in production, debug otherwise
+func (c *Config) EffectiveLogLevel() zapcore.Level {
+	if level, err := zapcore.ParseLevel(c.LogLevel); c.LogLevel != "" && err == nil {
+		return level
+	}
+
+	if c.Env == "production" {
+		return zapcore.InfoLevel
+	}
 
-	// # Viper unmarshals the loaded env varialbes into the struct
-	if err := viper.Unmarshal(config); err != nil {
-		return nil, err
+	return zapcore.DebugLevel
+}
+
+// Redacted returns config safe to log
+func (c *Config) Redacted() map[string]any {
+	return pkgconfig.Redacted(c)
+}
+
+// runConfigCommand runs `config print` or `config validate`
+func runConfigCommand(config *Config, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: core config print | core config validate")
+	}
+
+	switch args[1] {
+	case "print":
+		encoder := yaml.NewEncoder(os.Stdout)
+		defer encoder.Close()
+		return encoder.Encode(config.Redacted())
+	case "validate":
+		if _, _, err := resolveSecrets(context.Background(), config); err != nil {
+			return err
+		}
+		if err := config.Validate(); err != nil {
+			return err
+		}
+		fmt.Println("Config is valid")
+		return nil
 	}
 
-	return config, nil
+	return fmt.Errorf("unknown config command: %s", args[1])
 }
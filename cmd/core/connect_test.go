@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/api/v1/go/proto/protoconnect"
+	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+func TestIntSignInProtocols(t *testing.T) {
+	ctx := context.Background()
+
+	testDeps, err := inttests.InitTestDeps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		err := testDeps.Cleanup()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	seed, err := inttests.Seed(ctx, testDeps.FeaturesConfig, testDeps.MainDbConnection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, _ := startTestServer(t, "in-process", testDeps.Deps)
+
+	signInRequest := func(password string) *proto.SignInCallRequest {
+		return &proto.SignInCallRequest{
+			Name: "SignIn",
+			Id:   uuid.New().String(),
+			Params: &proto.SignInCallRequest_Params{
+				Email:    seed.User.Email,
+				Password: password,
+			},
+		}
+	}
+
+	t.Run("grpc", func(t *testing.T) {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.Nil(t, err)
+		defer conn.Close()
+		client := proto.NewMainApiClient(conn)
+
+		response, err := client.SignIn(ctx, signInRequest("1234"))
+		assert.Nil(t, err)
+		assert.NotEmpty(t, response.Result.GetSuccess().GetToken())
+
+		_, err = client.SignIn(ctx, signInRequest("wrong"))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, "Incorrect email or password", status.Convert(err).Message())
+	})
+
+	for name, opts := range map[string][]connect.ClientOption{
+		"connect":      nil,
+		"connect json": {connect.WithProtoJSON()},
+		"grpc-web":     {connect.WithGRPCWeb()},
+		"connect h2":   nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			httpClient := http.DefaultClient
+			if strings.HasSuffix(name, "h2") {
+				httpClient = h2cClient()
+			}
+			client := protoconnect.NewMainApiClient(httpClient, "http://"+address, opts...)
+
+			response, err := client.SignIn(ctx, connect.NewRequest(signInRequest("1234")))
+			assert.Nil(t, err)
+			assert.NotEmpty(t, response.Msg.Result.GetSuccess().GetToken())
+
+			_, err = client.SignIn(ctx, connect.NewRequest(signInRequest("wrong")))
+			assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			var connectErr *connect.Error
+			assert.ErrorAs(t, err, &connectErr)
+			assert.Equal(t, "Incorrect email or password", connectErr.Message())
+		})
+	}
+}
@@ -29,7 +29,7 @@ func initFirstAdmin(ctx context.Context, config *Config, db *sqlx.DB) terrors.Er
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		return terrors.NewPrivateError("can't generate password hash")
+		return terrors.WrapPrivateError(err, "can't generate password hash")
 	}
 
 	newUser := maindb.NewInsertableUserModel(
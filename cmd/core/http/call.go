@@ -0,0 +1,183 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	nethttp "net/http"
+	"sync"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/pkg/i18n"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// MaxCallBatchSize limits number of calls in one batch
+const MaxCallBatchSize = 100
+
+// CallHandler serves `POST /api/v1/call`: dispatches request by `name` to the
+// service method with the same name through Conn, so calls pass gRPC interceptors.
+// Body is one request or JSON array of requests, batched calls run concurrently
+// and results keep order and `id` of requests. Errors are returned as `Failure` result.
+type CallHandler struct {
+	Conn    grpc.ClientConnInterface
+	Service protoreflect.ServiceDescriptor
+	// I18n localizes failures of calls that didn't reach gRPC, optional
+	I18n *i18n.Bundle
+
+	Marshaler   protojson.MarshalOptions
+	Unmarshaler protojson.UnmarshalOptions
+}
+
+func NewCallHandler(conn grpc.ClientConnInterface, service protoreflect.ServiceDescriptor, bundle *i18n.Bundle) *CallHandler {
+	return &CallHandler{
+		Conn:        conn,
+		Service:     service,
+		I18n:        bundle,
+		Marshaler:   protojson.MarshalOptions{EmitUnpopulated: true},
+		Unmarshaler: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+type callEnvelope struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+}
+
+// callFailure is `Failure` result for calls without response type (unknown name, invalid body)
+func (h *CallHandler) callFailure(c echo.Context, id string, tErr terrors.Error) protobuf.Message {
+	if h.I18n != nil {
+		locale := h.I18n.Negotiate(c.Request().Header.Get("Accept-Language"))
+		tErr = terrors.Localize(tErr, h.I18n.Translator(locale))
+	}
+
+	return &proto.DefaultCallResponse{
+		Id: id,
+		Result: &proto.DefaultCallResponse_Result{
+			Result: &proto.DefaultCallResponse_Result_Failure{
+				Failure: &proto.Failure{
+					Message: tErr.GetPublicMessage(),
+					Code:    int32(tErr.GetCode()),
+					Data:    failureData(tErr.GetData()),
+				},
+			},
+		},
+	}
+}
+
+// withMetaToken sets `meta.token` of request when caller didn't pass one
+func withMetaToken(request protobuf.Message, token string) {
+	metaField := request.ProtoReflect().Descriptor().Fields().ByName("meta")
+	if token == "" || metaField == nil || metaField.Message() == nil {
+		return
+	}
+
+	meta := request.ProtoReflect().Mutable(metaField).Message()
+	tokenField := meta.Descriptor().Fields().ByName("token")
+	if tokenField != nil && !meta.Has(tokenField) {
+		meta.Set(tokenField, protoreflect.ValueOfString(token))
+	}
+}
+
+// call invokes one call, token is used when request has no `meta.token`
+func (h *CallHandler) call(c echo.Context, raw json.RawMessage, token string) protobuf.Message {
+	envelope := callEnvelope{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return h.callFailure(c, "", terrors.NewValidationError("Invalid call", nil).WithReason("call.invalid", nil))
+	}
+
+	method := h.Service.Methods().ByName(protoreflect.Name(envelope.Name))
+	if method == nil || method.IsStreamingClient() || method.IsStreamingServer() {
+		return h.callFailure(c, envelope.Id, terrors.NewNotFoundError(fmt.Sprintf("Unknown call %s", envelope.Name), nil).WithReason("call.unknown", map[string]any{"name": envelope.Name}))
+	}
+
+	requestType, err := protoregistry.GlobalTypes.FindMessageByName(method.Input().FullName())
+	if err != nil {
+		return h.callFailure(c, envelope.Id, terrors.WrapPrivateError(err, "call request type"))
+	}
+	responseType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return h.callFailure(c, envelope.Id, terrors.WrapPrivateError(err, "call response type"))
+	}
+
+	request := requestType.New().Interface()
+	if err := h.Unmarshaler.Unmarshal(raw, request); err != nil {
+		return h.callFailure(c, envelope.Id, terrors.NewValidationError("Invalid call", nil).WithReason("call.invalid", nil))
+	}
+	withMetaToken(request, token)
+
+	ctx := c.Request().Context()
+	ctx = metadata.NewOutgoingContext(ctx, outgoingMetadata(ctx, c.Request().Header, c.Request().RemoteAddr))
+
+	fullMethod := fmt.Sprintf("/%s/%s", h.Service.FullName(), method.Name())
+	response := responseType.New().Interface()
+
+	if err := h.Conn.Invoke(ctx, fullMethod, request, response); err != nil {
+		tErr, ok := terrors.FromGRPCError(err)
+		if !ok {
+			tErr = terrors.WrapPrivateError(err, err.Error())
+		}
+
+		if failure, ok := NewFailureResponse(fullMethod, request, tErr); ok {
+			return failure
+		}
+
+		return h.callFailure(c, envelope.Id, tErr)
+	}
+
+	return response
+}
+
+func (h *CallHandler) Handle(c echo.Context) error {
+	body := json.RawMessage{}
+	if err := json.NewDecoder(c.Request().Body).Decode(&body); err != nil {
+		return terrors.NewValidationError("Invalid call", nil).WithReason("call.invalid", nil)
+	}
+
+	// # Single call
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+		result, err := h.Marshaler.Marshal(h.call(c, body, ""))
+		if err != nil {
+			return err
+		}
+
+		return c.JSONBlob(nethttp.StatusOK, result)
+	}
+
+	// # Batch
+	batch := []json.RawMessage{}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return terrors.NewValidationError("Invalid call", nil).WithReason("call.invalid", nil)
+	}
+	if len(batch) > MaxCallBatchSize {
+		return terrors.NewValidationError(fmt.Sprintf("Batch must have at most %d calls", MaxCallBatchSize), nil)
+	}
+
+	results := make([]json.RawMessage, len(batch))
+	errs := make([]error, len(batch))
+
+	wg := sync.WaitGroup{}
+	for i, raw := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = h.Marshaler.Marshal(h.call(c, raw, ""))
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(nethttp.StatusOK, results)
+}
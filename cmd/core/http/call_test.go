@@ -0,0 +1,147 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type callTestServer struct {
+	proto.UnimplementedMainApiServer
+}
+
+func (callTestServer) SignIn(ctx context.Context, request *proto.SignInCallRequest) (*proto.SignInCallResponse, error) {
+	if request.Params.GetPassword() != "1234" {
+		return nil, terrors.NewValidationError("Incorrect email or password", nil)
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	return &proto.SignInCallResponse{
+		Id: request.Id,
+		Result: &proto.SignInCallResponse_Result{
+			Result: &proto.SignInCallResponse_Result_Success_{
+				Success: &proto.SignInCallResponse_Result_Success{Token: strings.Join(md.Get("auth"), "")},
+			},
+		},
+	}, nil
+}
+
+func (callTestServer) SignUp(ctx context.Context, request *proto.SignUpCallRequest) (*proto.SignUpCallResponse, error) {
+	return &proto.SignUpCallResponse{
+		Id: request.Id,
+		Result: &proto.SignUpCallResponse_Result{
+			Result: &proto.SignUpCallResponse_Result_Success_{
+				Success: &proto.SignUpCallResponse_Result_Success{Token: request.GetMeta().GetToken()},
+			},
+		},
+	}, nil
+}
+
+// newCallTestHandler serves callTestServer on in-memory gRPC server
+func newCallTestHandler(t *testing.T) *httpapi.CallHandler {
+	l := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		terrors.UnaryServerInterceptor(),
+		httpapi.FailureResultUnaryInterceptor(httpapi.FailurePolicyPublic),
+	))
+	proto.RegisterMainApiServer(grpcServer, callTestServer{})
+	go grpcServer.Serve(l)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.Nil(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return httpapi.NewCallHandler(conn, proto.File_calls_proto.Services().ByName("MainApi"), nil)
+}
+
+func TestUnitCallHandler(t *testing.T) {
+	handler := newCallTestHandler(t)
+	e := echo.New()
+	e.POST("/api/v1/call", handler.Handle)
+
+	post := func(body string) (int, []byte) {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/call", strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", "token-1")
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		return recorder.Code, recorder.Body.Bytes()
+	}
+
+	t.Run("single call", func(t *testing.T) {
+		code, body := post(`{"name": "SignIn", "id": "1", "params": {"email": "a@b.c", "password": "1234"}}`)
+		assert.Equal(t, http.StatusOK, code)
+
+		result := map[string]any{}
+		assert.Nil(t, json.Unmarshal(body, &result))
+		assert.Equal(t, "1", result["id"])
+		assert.Equal(t, "token-1", result["result"].(map[string]any)["success"].(map[string]any)["token"])
+	})
+
+	t.Run("batch keeps order and ids", func(t *testing.T) {
+		code, body := post(`[
+			{"name": "SignIn", "id": "ok", "params": {"email": "a@b.c", "password": "1234"}},
+			{"name": "SignIn", "id": "wrong", "params": {"email": "a@b.c", "password": "wrong"}},
+			{"name": "Unknown", "id": "unknown"},
+			{"name": "SignIn", "id": "invalid", "params": "oops"}
+		]`)
+		assert.Equal(t, http.StatusOK, code)
+
+		results := []map[string]any{}
+		assert.Nil(t, json.Unmarshal(body, &results))
+		assert.Len(t, results, 4)
+
+		failure := func(i int) map[string]any {
+			return results[i]["result"].(map[string]any)["failure"].(map[string]any)
+		}
+
+		assert.Equal(t, "ok", results[0]["id"])
+		assert.NotNil(t, results[0]["result"].(map[string]any)["success"])
+
+		assert.Equal(t, "wrong", results[1]["id"])
+		assert.Equal(t, "Incorrect email or password", failure(1)["message"])
+		assert.Equal(t, float64(http.StatusBadRequest), failure(1)["code"])
+
+		assert.Equal(t, "unknown", results[2]["id"])
+		assert.Equal(t, "Unknown call Unknown", failure(2)["message"])
+		assert.Equal(t, float64(http.StatusNotFound), failure(2)["code"])
+
+		assert.Equal(t, "invalid", results[3]["id"])
+		assert.Equal(t, float64(http.StatusBadRequest), failure(3)["code"])
+	})
+
+	t.Run("batch is limited", func(t *testing.T) {
+		calls := make([]string, httpapi.MaxCallBatchSize+1)
+		for i := range calls {
+			calls[i] = `{"name": "SignIn"}`
+		}
+
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/call", strings.NewReader("["+strings.Join(calls, ",")+"]"))
+		err := handler.Handle(e.NewContext(request, httptest.NewRecorder()))
+
+		tErr, ok := err.(terrors.Error)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, tErr.GetCode())
+	})
+}
@@ -0,0 +1,176 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	nethttp "net/http"
+	"strings"
+
+	"connectrpc.com/connect"
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
+	"github.com/Dionid/go-boiler/internal/idempotency"
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/Dionid/go-boiler/pkg/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// MainApiConnectService serves MainApi over Connect and gRPC-Web by forwarding
+// calls to gRPC server through in-process Client, so they pass the same
+// interceptor chain as native gRPC and gateway calls
+type MainApiConnectService struct {
+	Client proto.MainApiClient
+}
+
+// # Auth
+
+func (service *MainApiConnectService) SignIn(ctx context.Context, request *connect.Request[proto.SignInCallRequest]) (*connect.Response[proto.SignInCallResponse], error) {
+	return forward(ctx, request, service.Client.SignIn)
+}
+
+func (service *MainApiConnectService) SignUp(ctx context.Context, request *connect.Request[proto.SignUpCallRequest]) (*connect.Response[proto.SignUpCallResponse], error) {
+	return forward(ctx, request, service.Client.SignUp)
+}
+
+// # Events
+
+func (service *MainApiConnectService) SubscribeEvents(ctx context.Context, request *connect.Request[proto.SubscribeEventsCallRequest], stream *connect.ServerStream[proto.SubscribeEventsCallResponse]) error {
+	ctx = metadata.NewOutgoingContext(ctx, outgoingMetadata(ctx, request.Header(), request.Peer().Addr))
+
+	client, err := service.Client.SubscribeEvents(ctx, request.Msg)
+	if err != nil {
+		return connectError(err, nil, nil)
+	}
+
+	// # Subscribed header is flushed to client right away, stream without it has failed
+	header, err := client.Header()
+	if err != nil {
+		return connectError(err, nil, client.Trailer())
+	}
+	if len(header.Get(fsubscribeevents.HeaderSubscribed)) == 0 {
+		_, err := client.Recv()
+		return connectError(err, nil, client.Trailer())
+	}
+	copyMetadata(header, stream.ResponseHeader().Add)
+	if err := stream.Send(nil); err != nil {
+		return err
+	}
+
+	for {
+		response, err := client.Recv()
+		if errors.Is(err, io.EOF) {
+			copyMetadata(client.Trailer(), stream.ResponseTrailer().Add)
+			return nil
+		}
+		if err != nil {
+			return connectError(err, nil, client.Trailer())
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+}
+
+// # Admin
+
+func (service *MainApiConnectService) GetConfig(ctx context.Context, request *connect.Request[proto.GetConfigCallRequest]) (*connect.Response[proto.GetConfigCallResponse], error) {
+	return forward(ctx, request, service.Client.GetConfig)
+}
+
+// # Forwarding
+
+// outgoingMetadata forwards same headers as gateway does, ctx is request context
+func outgoingMetadata(ctx context.Context, header nethttp.Header, remoteAddr string) metadata.MD {
+	md := metadata.MD{}
+
+	// # Like gateway, remote address is the last X-Forwarded-For entry
+	forwardedFor := header.Values("X-Forwarded-For")
+	if remoteIP, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		forwardedFor = append(forwardedFor, remoteIP)
+	}
+	if len(forwardedFor) > 0 {
+		md.Set("x-forwarded-for", strings.Join(forwardedFor, ", "))
+	}
+
+	if value := header.Get("Authorization"); value != "" {
+		md.Set("auth", value)
+	}
+	if value := header.Get(requestid.Header); value != "" {
+		md.Set(requestid.MetadataKey, value)
+	}
+	if values := header.Values("Accept-Language"); len(values) > 0 {
+		md.Set("accept-language", values...)
+	}
+	if value := header.Get(idempotency.Header); value != "" {
+		md.Set(idempotency.MetadataKey, value)
+	}
+
+	return metadata.Join(md, certs.Metadata(ctx))
+}
+
+// copyMetadata copies application metadata, transport headers stay with gRPC
+func copyMetadata(md metadata.MD, set func(key string, value string)) {
+	for key, values := range md {
+		if key == "content-type" || strings.HasPrefix(key, "grpc-") {
+			continue
+		}
+
+		for _, value := range values {
+			set(key, value)
+		}
+	}
+}
+
+// connectError keeps code, message and details of gRPC status
+func connectError(err error, header metadata.MD, trailer metadata.MD) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	connectErr := connect.NewError(connect.Code(st.Code()), errors.New(st.Message()))
+
+	for _, detail := range st.Proto().GetDetails() {
+		message, err := anypb.UnmarshalNew(detail, protobuf.UnmarshalOptions{})
+		if err != nil {
+			continue
+		}
+
+		if errorDetail, err := connect.NewErrorDetail(message); err == nil {
+			connectErr.AddDetail(errorDetail)
+		}
+	}
+
+	copyMetadata(header, connectErr.Meta().Add)
+	copyMetadata(trailer, connectErr.Meta().Add)
+
+	return connectErr
+}
+
+func forward[Req any, Resp any](
+	ctx context.Context,
+	request *connect.Request[Req],
+	call func(ctx context.Context, request *Req, opts ...grpc.CallOption) (*Resp, error),
+) (*connect.Response[Resp], error) {
+	header, trailer := metadata.MD{}, metadata.MD{}
+
+	ctx = metadata.NewOutgoingContext(ctx, outgoingMetadata(ctx, request.Header(), request.Peer().Addr))
+
+	resp, err := call(ctx, request.Msg, grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		return nil, connectError(err, header, trailer)
+	}
+
+	response := connect.NewResponse(resp)
+	copyMetadata(header, response.Header().Add)
+	copyMetadata(trailer, response.Trailer().Add)
+
+	return response, nil
+}
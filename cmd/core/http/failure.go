@@ -0,0 +1,145 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"google.golang.org/grpc"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// FailurePolicy decides which errors are returned as `Failure` result
+// instead of transport error
+type FailurePolicy string
+
+const (
+	// FailurePolicyTransport returns every error as transport error
+	FailurePolicyTransport FailurePolicy = "transport"
+	// FailurePolicyPublic returns terrors.PublicError as `Failure` result,
+	// private errors stay transport errors
+	FailurePolicyPublic FailurePolicy = "public"
+)
+
+func ParseFailurePolicy(value string) (FailurePolicy, error) {
+	switch FailurePolicy(strings.ToLower(value)) {
+	case "", FailurePolicyTransport:
+		return FailurePolicyTransport, nil
+	case FailurePolicyPublic:
+		return FailurePolicyPublic, nil
+	}
+
+	return "", fmt.Errorf("unknown failure policy: %s", value)
+}
+
+var failureFullName = (&proto.Failure{}).ProtoReflect().Descriptor().FullName()
+
+// NewFailureResponse creates response message of `method` with populated
+// `result.failure` and `id` copied from request.
+// Returns false if response message doesn't follow call response shape.
+func NewFailureResponse(method string, request any, tErr terrors.Error) (protobuf.Message, bool) {
+	methodName := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(method, "/"), "/", "."))
+
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(methodName)
+	if err != nil {
+		return nil, false
+	}
+
+	methodDesc, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, false
+	}
+
+	responseType, err := protoregistry.GlobalTypes.FindMessageByName(methodDesc.Output().FullName())
+	if err != nil {
+		return nil, false
+	}
+
+	response := responseType.New()
+
+	resultField := response.Descriptor().Fields().ByName("result")
+	if resultField == nil || resultField.Message() == nil {
+		return nil, false
+	}
+
+	var failureField protoreflect.FieldDescriptor
+	resultFields := resultField.Message().Fields()
+	for i := 0; i < resultFields.Len(); i++ {
+		if resultFields.Get(i).Message() != nil && resultFields.Get(i).Message().FullName() == failureFullName {
+			failureField = resultFields.Get(i)
+			break
+		}
+	}
+	if failureField == nil {
+		return nil, false
+	}
+
+	failure := &proto.Failure{
+		Message: tErr.GetPublicMessage(),
+		Code:    int32(tErr.GetCode()),
+		Data:    failureData(tErr.GetData()),
+	}
+
+	result := response.NewField(resultField).Message()
+	result.Set(failureField, protoreflect.ValueOfMessage(failure.ProtoReflect()))
+	response.Set(resultField, protoreflect.ValueOfMessage(result))
+
+	if idField := response.Descriptor().Fields().ByName("id"); idField != nil && idField.Kind() == protoreflect.StringKind {
+		if req, ok := request.(proto.Request); ok {
+			response.Set(idField, protoreflect.ValueOfString(req.GetId()))
+		}
+	}
+
+	return response.Interface(), true
+}
+
+func failureData(data any) *anypb.Any {
+	if data == nil {
+		return nil
+	}
+
+	msg, ok := data.(protobuf.Message)
+	if !ok {
+		value, err := structpb.NewValue(data)
+		if err != nil {
+			return nil
+		}
+		msg = value
+	}
+
+	result, err := anypb.New(msg)
+	if err != nil {
+		return nil
+	}
+
+	return result
+}
+
+// FailureResultUnaryInterceptor converts errors returned by handlers into
+// `Failure` result according to policy
+func FailureResultUnaryInterceptor(policy FailurePolicy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err == nil || policy != FailurePolicyPublic {
+			return resp, err
+		}
+
+		tErr, ok := err.(terrors.PublicError)
+		if !ok {
+			return resp, err
+		}
+
+		failureResp, ok := NewFailureResponse(info.FullMethod, req, tErr)
+		if !ok {
+			return resp, err
+		}
+
+		return failureResp, nil
+	}
+}
@@ -0,0 +1,60 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestUnitFailureResult(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: proto.MainApi_SignIn_FullMethodName}
+	request := &proto.SignInCallRequest{Name: "SignIn", Id: "call-1"}
+
+	t.Run("public error becomes failure", func(t *testing.T) {
+		interceptor := httpapi.FailureResultUnaryInterceptor(httpapi.FailurePolicyPublic)
+
+		resp, err := interceptor(context.Background(), request, info, func(ctx context.Context, req any) (any, error) {
+			return nil, terrors.NewValidationError("Incorrect email or password", map[string]any{"field": "email"})
+		})
+		assert.Nil(t, err)
+
+		signInResp, ok := resp.(*proto.SignInCallResponse)
+		assert.True(t, ok)
+		assert.Equal(t, "call-1", signInResp.Id)
+
+		failure := signInResp.GetResult().GetFailure()
+		assert.NotNil(t, failure)
+		assert.Equal(t, "Incorrect email or password", failure.Message)
+		assert.Equal(t, int32(http.StatusBadRequest), failure.Code)
+
+		data := &structpb.Value{}
+		assert.Nil(t, failure.Data.UnmarshalTo(data))
+		assert.Equal(t, "email", data.GetStructValue().GetFields()["field"].GetStringValue())
+	})
+
+	t.Run("private error stays transport error", func(t *testing.T) {
+		interceptor := httpapi.FailureResultUnaryInterceptor(httpapi.FailurePolicyPublic)
+
+		resp, err := interceptor(context.Background(), request, info, func(ctx context.Context, req any) (any, error) {
+			return nil, terrors.NewPrivateError("db is down")
+		})
+		assert.Nil(t, resp)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("transport policy", func(t *testing.T) {
+		interceptor := httpapi.FailureResultUnaryInterceptor(httpapi.FailurePolicyTransport)
+
+		_, err := interceptor(context.Background(), request, info, func(ctx context.Context, req any) (any, error) {
+			return nil, terrors.NewValidationError("Incorrect email or password", nil)
+		})
+		assert.NotNil(t, err)
+	})
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/Dionid/go-boiler/api/v1/go/proto"
 	"github.com/Dionid/go-boiler/features"
+	fgetconfig "github.com/Dionid/go-boiler/features/get-config"
 	fsignin "github.com/Dionid/go-boiler/features/sign-in"
 	fsignup "github.com/Dionid/go-boiler/features/sign-up"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
 )
 
 type MainApiService struct {
@@ -23,3 +25,19 @@ func (service *MainApiService) SignIn(ctx context.Context, request *proto.SignIn
 func (service *MainApiService) SignUp(ctx context.Context, request *proto.SignUpCallRequest) (*proto.SignUpCallResponse, error) {
 	return fsignup.SignUp(ctx, service.Deps, request)
 }
+
+// # Events
+
+func (service *MainApiService) SubscribeEvents(request *proto.SubscribeEventsCallRequest, stream proto.MainApi_SubscribeEventsServer) error {
+	if err := fsubscribeevents.SubscribeEvents(stream.Context(), service.Deps, request, stream); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// # Admin
+
+func (service *MainApiService) GetConfig(ctx context.Context, request *proto.GetConfigCallRequest) (*proto.GetConfigCallResponse, error) {
+	return fgetconfig.GetConfig(ctx, service.Deps, request)
+}
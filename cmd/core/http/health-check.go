@@ -3,11 +3,29 @@ package http
 import (
 	"net/http"
 
+	"github.com/Dionid/go-boiler/pkg/health"
 	"github.com/labstack/echo/v4"
 )
 
-func HealthCheck(c echo.Context) error {
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"data": "Server is up and running",
-	})
+func healthReport(c echo.Context, report health.Report) error {
+	code := http.StatusOK
+	if !report.Ok() {
+		code = http.StatusServiceUnavailable
+	}
+
+	return c.JSON(code, report)
+}
+
+// Liveness answers 503 only if process must be restarted
+func Liveness(registry *health.Registry) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return healthReport(c, registry.Live(c.Request().Context()))
+	}
+}
+
+// Readiness answers 503 if dependencies are unhealthy or graceful shutdown has started
+func Readiness(registry *health.Registry) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return healthReport(c, registry.Ready(c.Request().Context()))
+	}
 }
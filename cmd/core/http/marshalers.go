@@ -0,0 +1,105 @@
+package http
+
+import (
+	"mime"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	MIMEProtobuf = "application/x-protobuf"
+	MIMENDJSON   = "application/x-ndjson"
+)
+
+type JSONOptions struct {
+	// EmitDefaults writes fields with zero values
+	EmitDefaults bool
+	// UseProtoNames writes `snake_case` field names of proto files instead of `camelCase`
+	UseProtoNames bool
+}
+
+// protoMarshaler writes protobuf binary with its own content type instead of `application/octet-stream`
+type protoMarshaler struct {
+	runtime.ProtoMarshaller
+}
+
+func (m *protoMarshaler) ContentType(_ any) string {
+	return MIMEProtobuf
+}
+
+// ndjsonMarshaler writes stream as newline delimited JSON messages
+type ndjsonMarshaler struct {
+	runtime.JSONPb
+}
+
+func (m *ndjsonMarshaler) ContentType(_ any) string {
+	return MIMENDJSON
+}
+
+// GatewayMarshalerOptions register JSON (default), protobuf binary and NDJSON marshalers,
+// gateway picks one by `Accept` for response and by `Content-Type` for request
+func GatewayMarshalerOptions(options JSONOptions) []runtime.ServeMuxOption {
+	jsonPb := runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			EmitUnpopulated: options.EmitDefaults,
+			UseProtoNames:   options.UseProtoNames,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			DiscardUnknown: true,
+		},
+	}
+
+	json := &runtime.HTTPBodyMarshaler{Marshaler: &jsonPb}
+
+	return []runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, json),
+		runtime.WithMarshalerOption("application/json", json),
+		runtime.WithMarshalerOption(MIMEProtobuf, &protoMarshaler{}),
+		runtime.WithMarshalerOption(MIMENDJSON, &ndjsonMarshaler{JSONPb: jsonPb}),
+	}
+}
+
+var gatewayMediaTypes = []string{"application/json", MIMEProtobuf, MIMENDJSON}
+
+// NegotiateAccept replaces `Accept` list with supported media type of highest q,
+// because gateway picks marshaler only by exact `Accept` value.
+// Without supported type response is marshaled like request.
+func NegotiateAccept(next nethttp.Handler) nethttp.Handler {
+	return nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		best, bestQ := "", 0.0
+
+		for _, value := range r.Header.Values("Accept") {
+			for _, part := range strings.Split(value, ",") {
+				mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+				if err != nil {
+					continue
+				}
+
+				q := 1.0
+				if value, ok := params["q"]; ok {
+					if q, err = strconv.ParseFloat(value, 64); err != nil {
+						continue
+					}
+				}
+
+				for _, supported := range gatewayMediaTypes {
+					if mediaType == supported && q > bestQ {
+						best, bestQ = supported, q
+					}
+				}
+			}
+		}
+
+		if best == "" {
+			r.Header.Del("Accept")
+		} else {
+			r.Header.Set("Accept", best)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	nethttp "net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// EventsHandler bridges SubscribeEvents stream to Server-Sent Events for browsers:
+// `GET /api/v1/events?topics=a,b` with token in `Authorization` header or `token` query param
+// moved to it by QueryTokenMiddleware.
+// Event name is topic and event id is used by EventSource to resume by `Last-Event-ID`,
+// `reset` event means some events were missed and client should reload its state.
+type EventsHandler struct {
+	Client proto.MainApiClient
+	// Heartbeat is interval of comments that keep idle connection open
+	Heartbeat time.Duration
+}
+
+func (h *EventsHandler) request(c echo.Context) (*proto.SubscribeEventsCallRequest, error) {
+	request := &proto.SubscribeEventsCallRequest{
+		Name:   "SubscribeEvents",
+		Meta:   &proto.Meta{},
+		Params: &proto.SubscribeEventsCallRequest_Params{},
+	}
+
+	token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if token != "" {
+		request.Meta.Token = &token
+	}
+
+	for _, topics := range c.QueryParams()["topics"] {
+		request.Params.Topics = append(request.Params.Topics, strings.Split(topics, ",")...)
+	}
+
+	lastEventId := c.Request().Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = c.QueryParam("lastEventId")
+	}
+	if lastEventId != "" {
+		id, err := strconv.ParseUint(lastEventId, 10, 64)
+		if err != nil {
+			return nil, terrors.NewValidationError("Invalid last event id", nil).WithReason("events.invalid_last_event_id", nil)
+		}
+		request.Params.LastEventId = &id
+	}
+
+	return request, nil
+}
+
+func (h *EventsHandler) Handle(c echo.Context) error {
+	request, err := h.request(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	ctx = metadata.NewOutgoingContext(ctx, outgoingMetadata(ctx, c.Request().Header, c.Request().RemoteAddr))
+
+	stream, err := h.Client.SubscribeEvents(ctx, request)
+	if err != nil {
+		return grpcToTerror(err)
+	}
+
+	// # Stream without subscribed header has failed
+	header, err := stream.Header()
+	if err != nil {
+		return grpcToTerror(err)
+	}
+	if len(header.Get(fsubscribeevents.HeaderSubscribed)) == 0 {
+		_, err := stream.Recv()
+		return grpcToTerror(err)
+	}
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set(echo.HeaderCacheControl, "no-cache")
+	response.Header().Set("X-Accel-Buffering", "no")
+	response.WriteHeader(nethttp.StatusOK)
+	response.Flush()
+
+	messages := make(chan *proto.SubscribeEventsCallResponse)
+	streamErr := make(chan error, 1)
+	go func() {
+		for {
+			message, err := stream.Recv()
+			if err != nil {
+				streamErr <- err
+				return
+			}
+
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(h.Heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := io.WriteString(response, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+		case message := <-messages:
+			success := message.GetResult().GetSuccess()
+			if success.GetReset_() {
+				io.WriteString(response, "event: reset\ndata: {}\n\n")
+				break
+			}
+
+			data, err := protojson.Marshal(success.GetData())
+			if err != nil {
+				return nil
+			}
+			if _, err := fmt.Fprintf(response, "id: %d\nevent: %s\ndata: %s\n\n", success.GetEventId(), success.GetTopic(), data); err != nil {
+				return nil
+			}
+		case err := <-streamErr:
+			// # Status is already sent, so error is the last event
+			if !errors.Is(err, io.EOF) {
+				tErr := grpcToTerror(err)
+				data, _ := protojson.Marshal(&proto.Failure{Message: tErr.GetPublicMessage(), Code: int32(tErr.GetCode())})
+				fmt.Fprintf(response, "event: error\ndata: %s\n\n", data)
+			}
+			response.Flush()
+			return nil
+		}
+
+		response.Flush()
+	}
+}
+
+func grpcToTerror(err error) terrors.Error {
+	if tErr, ok := terrors.FromGRPCError(err); ok {
+		return tErr
+	}
+
+	return terrors.WrapPrivateError(err, "in subscribe events")
+}
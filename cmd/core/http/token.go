@@ -0,0 +1,31 @@
+package http
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// QueryTokenMiddleware moves `token` query param, used by browser WebSocket and EventSource
+// that can't set headers, to `Authorization` header, so it isn't logged or traced with URI.
+// Must run before logging and tracing middlewares, e.g. by echo Pre.
+func QueryTokenMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			query := req.URL.Query()
+			if !query.Has("token") {
+				return next(c)
+			}
+
+			if token := query.Get("token"); token != "" && req.Header.Get("Authorization") == "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+
+			query.Del("token")
+			req.URL.RawQuery = query.Encode()
+			req.RequestURI = req.URL.RequestURI()
+
+			return next(c)
+		}
+	}
+}
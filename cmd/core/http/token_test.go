@@ -0,0 +1,56 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitQueryTokenMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Pre(httpapi.QueryTokenMiddleware())
+	e.GET("/ws", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{
+			"authorization": c.Request().Header.Get("Authorization"),
+			"uri":           c.Request().RequestURI,
+			"token":         c.QueryParam("token"),
+		})
+	})
+
+	call := func(uri string, authorization string) map[string]string {
+		request := httptest.NewRequest(http.MethodGet, uri, nil)
+		if authorization != "" {
+			request.Header.Set("Authorization", authorization)
+		}
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		result := map[string]string{}
+		assert.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+		return result
+	}
+
+	assert.Equal(t, map[string]string{
+		"authorization": "Bearer secret",
+		"uri":           "/ws?topics=a",
+		"token":         "",
+	}, call("/ws?topics=a&token=secret", ""))
+
+	// # Header wins, query token is still removed
+	assert.Equal(t, map[string]string{
+		"authorization": "Bearer header",
+		"uri":           "/ws",
+		"token":         "",
+	}, call("/ws?token=secret", "Bearer header"))
+
+	assert.Equal(t, map[string]string{
+		"authorization": "",
+		"uri":           "/ws?topics=a",
+		"token":         "",
+	}, call("/ws?topics=a", ""))
+}
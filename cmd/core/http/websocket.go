@@ -0,0 +1,285 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+	protobuf "google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// # Control calls handled by WebSocket connection itself
+const (
+	webSocketSubscribe   = "Subscribe"
+	webSocketUnsubscribe = "Unsubscribe"
+)
+
+type WebSocketConfig struct {
+	// IdleTimeout closes connection without client frames
+	IdleTimeout time.Duration
+	// MaxInFlight limits concurrent calls, next frames aren't read until one is finished
+	MaxInFlight int
+	// OutboxSize is how many frames may wait for slow client,
+	// subscription of client that stays behind is closed with the connection
+	OutboxSize int
+	// MaxFrameBytes limits size of client frame
+	MaxFrameBytes int
+}
+
+// WebSocketHandler serves calls and server push over one connection.
+// Client frames use the same `{name, id, meta, params}` envelope as CallHandler,
+// `Subscribe` / `Unsubscribe` calls with `params.topic` manage event subscriptions
+// authorized like SubscribeEvents.
+// Server frames are call responses and `{"event": {"topic", "data"}}` events.
+// Caller is authenticated once on upgrade by `Authorization` header or `token` query param
+// moved to it by QueryTokenMiddleware, the token is used for calls without `meta.token`.
+type WebSocketHandler struct {
+	Calls     *CallHandler
+	Events    *events.Bus
+	JwtSecret func() []byte
+	Config    WebSocketConfig
+	Logger    *zap.Logger
+}
+
+type webSocketTopicParams struct {
+	Params struct {
+		Topic string `json:"topic"`
+	} `json:"params"`
+}
+
+type webSocketEvent struct {
+	Event events.Event `json:"event"`
+}
+
+func (h *WebSocketHandler) Handle(c echo.Context) error {
+	token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+
+	var claims *auth.Claims
+	if token != "" {
+		var err error
+		claims, err = auth.ParseToken(h.JwtSecret(), token)
+		if err != nil {
+			return terrors.NewUnauthorizedError("invalid token", nil).WithReason("auth.invalid_token", nil)
+		}
+	}
+
+	// # Token is not sent by browser automatically, so origin check isn't needed
+	server := websocket.Server{
+		Handler: func(ws *websocket.Conn) {
+			ws.MaxPayloadBytes = h.Config.MaxFrameBytes
+			h.serve(c, ws, token, claims)
+		},
+	}
+	server.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
+
+type webSocketConn struct {
+	handler *WebSocketHandler
+	c       echo.Context
+	ws      *websocket.Conn
+	claims  *auth.Claims
+
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	outbox chan []byte
+
+	mu            sync.Mutex
+	subscriptions map[string]*events.Subscription
+}
+
+func (h *WebSocketHandler) serve(c echo.Context, ws *websocket.Conn, token string, claims *auth.Claims) {
+	// # Calls are canceled with connection
+	ctx, cancel := context.WithCancelCause(c.Request().Context())
+	c.SetRequest(c.Request().WithContext(ctx))
+	conn := &webSocketConn{
+		handler:       h,
+		c:             c,
+		ws:            ws,
+		claims:        claims,
+		ctx:           ctx,
+		cancel:        cancel,
+		outbox:        make(chan []byte, h.Config.OutboxSize),
+		subscriptions: map[string]*events.Subscription{},
+	}
+
+	wg := sync.WaitGroup{}
+	defer func() {
+		cancel(nil)
+		conn.unsubscribeAll()
+		wg.Wait()
+
+		if cause := context.Cause(ctx); cause != nil && cause != context.Canceled {
+			h.Logger.Debug("websocket closed", zap.Error(cause))
+		}
+	}()
+
+	// # Writer
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer ws.Close()
+		conn.write()
+	}()
+
+	// # Reader, in-flight limit holds reading when client sends faster than calls finish
+	inFlight := make(chan struct{}, h.Config.MaxInFlight)
+	for {
+		ws.SetReadDeadline(time.Now().Add(h.Config.IdleTimeout))
+
+		raw := []byte{}
+		if err := websocket.Message.Receive(ws, &raw); err != nil {
+			cancel(err)
+			return
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-inFlight }()
+			conn.send(conn.dispatch(raw, token))
+		}()
+	}
+}
+
+func (conn *webSocketConn) write() {
+	for {
+		select {
+		case <-conn.ctx.Done():
+			return
+		case frame := <-conn.outbox:
+			conn.ws.SetWriteDeadline(time.Now().Add(conn.handler.Config.IdleTimeout))
+			if err := websocket.Message.Send(conn.ws, string(frame)); err != nil {
+				conn.cancel(err)
+				return
+			}
+		}
+	}
+}
+
+// send waits for outbox, so slow client holds calls and event forwarding
+func (conn *webSocketConn) send(message any) {
+	var (
+		frame []byte
+		err   error
+	)
+	if msg, ok := message.(protobuf.Message); ok {
+		frame, err = conn.handler.Calls.Marshaler.Marshal(msg)
+	} else {
+		frame, err = json.Marshal(message)
+	}
+	if err != nil {
+		conn.cancel(err)
+		return
+	}
+
+	select {
+	case conn.outbox <- frame:
+	case <-conn.ctx.Done():
+	}
+}
+
+func (conn *webSocketConn) dispatch(raw json.RawMessage, token string) protobuf.Message {
+	envelope := callEnvelope{}
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return conn.handler.Calls.call(conn.c, raw, token)
+	}
+
+	switch envelope.Name {
+	case webSocketSubscribe, webSocketUnsubscribe:
+		params := webSocketTopicParams{}
+		if err := json.Unmarshal(raw, &params); err != nil || params.Params.Topic == "" {
+			return conn.handler.Calls.callFailure(conn.c, envelope.Id, terrors.NewValidationError("Invalid call", nil).WithReason("call.invalid", nil))
+		}
+
+		if envelope.Name == webSocketUnsubscribe {
+			conn.unsubscribe(params.Params.Topic)
+		} else if err := conn.subscribe(params.Params.Topic); err != nil {
+			return conn.handler.Calls.callFailure(conn.c, envelope.Id, err)
+		}
+
+		return &proto.DefaultCallResponse{
+			Id: envelope.Id,
+			Result: &proto.DefaultCallResponse_Result{
+				Result: &proto.DefaultCallResponse_Result_Success{Success: &emptypb.Empty{}},
+			},
+		}
+	default:
+		return conn.handler.Calls.call(conn.c, raw, token)
+	}
+}
+
+// # Subscriptions
+
+func (conn *webSocketConn) subscribe(topic string) terrors.Error {
+	if conn.claims == nil {
+		return terrors.NewUnauthorizedError("token is required", nil).WithReason("auth.token_required", nil)
+	}
+	if err := fsubscribeevents.AuthorizeTopics(conn.claims, []string{topic}); err != nil {
+		return err
+	}
+
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if conn.ctx.Err() != nil {
+		return terrors.NewPrivateError("connection is closed")
+	}
+	if _, ok := conn.subscriptions[topic]; ok {
+		return nil
+	}
+
+	subscription := conn.handler.Events.Subscribe([]string{topic}, conn.handler.Config.OutboxSize)
+	conn.subscriptions[topic] = subscription
+
+	go func() {
+		for event := range subscription.Events() {
+			conn.send(webSocketEvent{Event: event})
+		}
+
+		// # Client that can't keep up with events is disconnected instead of missing them silently
+		if err := subscription.Err(); err != nil {
+			conn.cancel(err)
+		}
+	}()
+
+	return nil
+}
+
+func (conn *webSocketConn) unsubscribe(topic string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	if subscription, ok := conn.subscriptions[topic]; ok {
+		subscription.Close()
+		delete(conn.subscriptions, topic)
+	}
+}
+
+func (conn *webSocketConn) unsubscribeAll() {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+
+	for topic, subscription := range conn.subscriptions {
+		subscription.Close()
+		delete(conn.subscriptions, topic)
+	}
+}
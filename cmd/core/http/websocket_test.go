@@ -0,0 +1,114 @@
+package http_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+)
+
+func TestUnitWebSocketHandler(t *testing.T) {
+	secret := []byte("secret")
+	bus := events.NewBus(10)
+
+	e := echo.New()
+	e.Pre(httpapi.QueryTokenMiddleware())
+	e.GET("/api/v1/ws", (&httpapi.WebSocketHandler{
+		Calls:     newCallTestHandler(t),
+		Events:    bus,
+		JwtSecret: func() []byte { return secret },
+		Config: httpapi.WebSocketConfig{
+			IdleTimeout:   500 * time.Millisecond,
+			MaxInFlight:   4,
+			OutboxSize:    4,
+			MaxFrameBytes: 1 << 20,
+		},
+		Logger: zap.NewNop(),
+	}).Handle)
+
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	userId := uuid.New()
+	token, err := auth.CreateToken(secret, 1000, userId, "user")
+	assert.Nil(t, err)
+
+	dial := func(token string) (*websocket.Conn, error) {
+		return websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/api/v1/ws?token="+token, "", server.URL)
+	}
+	receive := func(ws *websocket.Conn) map[string]any {
+		frame := map[string]any{}
+		assert.Nil(t, websocket.JSON.Receive(ws, &frame))
+		return frame
+	}
+
+	t.Run("calls use connection token", func(t *testing.T) {
+		ws, err := dial(token)
+		assert.Nil(t, err)
+		defer ws.Close()
+
+		assert.Nil(t, websocket.Message.Send(ws, `{"name": "SignUp", "id": "1"}`))
+
+		frame := receive(ws)
+		assert.Equal(t, "1", frame["id"])
+		assert.Equal(t, token, frame["result"].(map[string]any)["success"].(map[string]any)["token"])
+	})
+
+	t.Run("events of subscribed topics are pushed", func(t *testing.T) {
+		ws, err := dial(token)
+		assert.Nil(t, err)
+		defer ws.Close()
+
+		topic := fsubscribeevents.UserTopic(userId.String())
+		assert.Nil(t, websocket.Message.Send(ws, `{"name": "Subscribe", "id": "1", "params": {"topic": "`+topic+`"}}`))
+		frame := receive(ws)
+		assert.Equal(t, "1", frame["id"])
+		assert.NotNil(t, frame["result"].(map[string]any)["success"])
+
+		bus.Publish(topic, map[string]any{"status": "paid"})
+		frame = receive(ws)
+		assert.Equal(t, map[string]any{"id": float64(1), "topic": topic, "data": map[string]any{"status": "paid"}}, frame["event"])
+
+		assert.Nil(t, websocket.Message.Send(ws, `{"name": "Subscribe", "id": "2", "params": {"topic": "`+fsubscribeevents.UserTopic(uuid.NewString())+`"}}`))
+		frame = receive(ws)
+		assert.Equal(t, "2", frame["id"])
+		assert.Equal(t, float64(403), frame["result"].(map[string]any)["failure"].(map[string]any)["code"])
+	})
+
+	t.Run("anonymous connection can't subscribe", func(t *testing.T) {
+		ws, err := dial("")
+		assert.Nil(t, err)
+		defer ws.Close()
+
+		assert.Nil(t, websocket.Message.Send(ws, `{"name": "Subscribe", "id": "1", "params": {"topic": "orders"}}`))
+		frame := receive(ws)
+		assert.Equal(t, float64(401), frame["result"].(map[string]any)["failure"].(map[string]any)["code"])
+	})
+
+	t.Run("invalid token is rejected on upgrade", func(t *testing.T) {
+		_, err := dial("invalid")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("idle connection is closed", func(t *testing.T) {
+		ws, err := dial(token)
+		assert.Nil(t, err)
+		defer ws.Close()
+
+		started := time.Now()
+		ws.SetReadDeadline(started.Add(5 * time.Second))
+		raw := []byte{}
+		assert.NotNil(t, websocket.Message.Receive(ws, &raw))
+		assert.Less(t, time.Since(started), 5*time.Second)
+	})
+}
@@ -3,11 +3,26 @@ package main
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/Dionid/go-boiler/pkg/i18n"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/requestid"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/Dionid/go-boiler/pkg/tracing"
+	"github.com/brpaz/echozap"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 // InterceptorLogger adapts zap logger to interceptor logger.
@@ -32,6 +47,9 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 			}
 		}
 
+		f = append(f, tracing.ZapFields(ctx)...)
+		f = append(f, requestid.ZapFields(ctx)...)
+
 		logger := l.WithOptions(zap.AddCallerSkip(1)).With(f...)
 
 		switch lvl {
@@ -49,6 +67,169 @@ func InterceptorLogger(l *zap.Logger) logging.Logger {
 	})
 }
 
-func grpcPanicRecoveryHandler(p any) error {
-	return status.Errorf(codes.Unknown, "panic triggered: %v", p)
+// grpcPanicRecoveryHandler logs recovered panic and returns codes.Internal
+// without leaking panic value to the client
+func grpcPanicRecoveryHandler(logger *zap.Logger) recovery.RecoveryHandlerFunc {
+	return func(p any) error {
+		logger.Error("panic triggered", zap.Any("panic", p), zap.Stack("stack"))
+		return terrors.ToGRPCStatus(terrors.NewPrivateError(fmt.Sprintf("panic triggered: %v", p))).Err()
+	}
+}
+
+// localeUnaryInterceptor negotiates locale from `Meta.locale` or `Accept-Language`
+// (forwarded by gateway or sent by native client), stores it in context
+// and renders public message of returned terrors.Error in it
+func localeUnaryInterceptor(bundle *i18n.Bundle) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		preferences := []string{}
+
+		if request, ok := req.(auth.Request); ok {
+			preferences = append(preferences, request.GetMeta().GetLocale())
+		}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			preferences = append(preferences, md.Get("accept-language")...)
+			preferences = append(preferences, md.Get("grpcgateway-accept-language")...)
+		}
+
+		locale := bundle.Negotiate(preferences...)
+
+		resp, err := handler(i18n.WithLocale(ctx, locale), req)
+		if tErr, ok := err.(terrors.Error); ok {
+			return resp, terrors.Localize(tErr, bundle.Translator(locale))
+		}
+
+		return resp, err
+	}
+}
+
+// traceMetaUnaryInterceptor links `Meta.traceId` with current span:
+// trace id sent by client is recorded as span attribute,
+// empty one is filled with id of the current trace
+func traceMetaUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		request, ok := req.(auth.Request)
+		if !ok || request.GetMeta() == nil {
+			return handler(ctx, req)
+		}
+
+		meta := request.GetMeta()
+		if meta.TraceId != "" {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("meta.trace_id", meta.TraceId))
+		} else {
+			meta.TraceId = tracing.TraceId(ctx)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// clientIP is address of direct gRPC client or, when it's in-process bridge (gateway, Connect, calls)
+// or trusted proxy, the first `X-Forwarded-For` entry from the right that isn't trusted proxy
+func clientIP(ctx context.Context, trustedProxies []*net.IPNet) string {
+	forwardedFor := []string{}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		forwardedFor = md.Get("x-forwarded-for")
+	}
+
+	// # Bridges already appended HTTP remote address, other peers are the last hop
+	if !certs.IsInProcess(ctx) {
+		p, ok := peer.FromContext(ctx)
+		if !ok {
+			return ""
+		}
+		addr, ok := p.Addr.(*net.TCPAddr)
+		if !ok {
+			return ""
+		}
+		forwardedFor = append(forwardedFor, addr.IP.String())
+	}
+
+	return forwardedClientIP(forwardedFor, trustedProxies)
+}
+
+// forwardedClientIP walks `X-Forwarded-For` values, the last is direct peer, from the right
+// and skips trusted proxies: entries on the left of untrusted one can be set by client
+func forwardedClientIP(forwardedFor []string, trustedProxies []*net.IPNet) string {
+	entries := []string{}
+	for _, value := range forwardedFor {
+		for _, entry := range strings.Split(value, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		ip := net.ParseIP(entries[i])
+		trusted := false
+		for _, proxy := range trustedProxies {
+			trusted = trusted || ip != nil && proxy.Contains(ip)
+		}
+
+		if !trusted || i == 0 {
+			return entries[i]
+		}
+	}
+
+	return ""
+}
+
+// parseTrustedProxies parses TRUSTED_PROXIES, they are validated by config
+func parseTrustedProxies(cidrs []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, proxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("TRUSTED_PROXIES: %w", err)
+		}
+		proxies = append(proxies, proxy)
+	}
+
+	return proxies, nil
+}
+
+// rateLimitSubject identifies caller by `Meta.token` and client IP
+func rateLimitSubject(jwtSecret func() []byte, trustedProxies []*net.IPNet) func(ctx context.Context, req any) ratelimit.Subject {
+	return func(ctx context.Context, req any) ratelimit.Subject {
+		subject := ratelimit.Subject{IP: clientIP(ctx, trustedProxies)}
+
+		if request, ok := req.(auth.Request); ok {
+			if claims, err := auth.ClaimsFromRequest(jwtSecret(), request); err == nil {
+				subject.User = claims.UserId.String()
+			}
+		}
+
+		return subject
+	}
+}
+
+// webSocketRateLimitSubject identifies WebSocket upgrade by its token and client IP like clientIP
+func webSocketRateLimitSubject(jwtSecret func() []byte, trustedProxies []*net.IPNet) func(c echo.Context) ratelimit.Subject {
+	return func(c echo.Context) ratelimit.Subject {
+		forwardedFor := c.Request().Header.Values("X-Forwarded-For")
+		if ip, _, err := net.SplitHostPort(c.Request().RemoteAddr); err == nil {
+			forwardedFor = append(forwardedFor, ip)
+		}
+
+		subject := ratelimit.Subject{IP: forwardedClientIP(forwardedFor, trustedProxies)}
+
+		token := strings.TrimPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+		if claims, err := auth.ParseToken(jwtSecret(), token); token != "" && err == nil {
+			subject.User = claims.UserId.String()
+		}
+
+		return subject
+	}
+}
+
+// zapLoggerMiddleware logs requests like echozap, adding trace, span and request ids
+func zapLoggerMiddleware(logger *zap.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			requestLogger := tracing.Logger(ctx, logger).With(requestid.ZapFields(ctx)...)
+			return echozap.ZapLogger(requestLogger)(next)(c)
+		}
+	}
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestUnitClientIP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer listener.Close()
+
+	// # AuthInfo of connection accepted by TrustedListener
+	go func() {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		if err == nil {
+			conn.Close()
+		}
+	}()
+	conn, err := certs.TrustedListener(listener).Accept()
+	assert.Nil(t, err)
+	defer conn.Close()
+	_, inProcess, err := certs.Credentials().ServerHandshake(conn)
+	assert.Nil(t, err)
+
+	call := func(addr string, authInfo credentials.AuthInfo, forwardedFor ...string) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr:     &net.TCPAddr{IP: net.ParseIP(addr), Port: 5000},
+			AuthInfo: authInfo,
+		})
+		if len(forwardedFor) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", forwardedFor[0]))
+		}
+		return ctx
+	}
+
+	proxies, err := parseTrustedProxies([]string{"10.0.0.0/8"})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "127.0.0.1", clientIP(call("127.0.0.1", nil, "1.1.1.1"), nil))
+	assert.Equal(t, "8.8.8.8", clientIP(call("8.8.8.8", nil, "1.1.1.1"), proxies))
+	assert.Equal(t, "1.1.1.1", clientIP(call("127.0.0.1", inProcess, "2.2.2.2, 1.1.1.1"), nil))
+	assert.Equal(t, "1.1.1.1", clientIP(call("10.1.2.3", nil, "1.1.1.1"), proxies))
+	assert.Equal(t, "10.1.2.3", clientIP(call("10.1.2.3", nil), proxies))
+	assert.Equal(t, "", clientIP(context.Background(), proxies))
+
+	// # Proxies on the right are skipped, entries on the left of untrusted one are ignored
+	assert.Equal(t, "1.1.1.1", clientIP(call("127.0.0.1", inProcess, "1.1.1.1, 10.0.0.5"), proxies))
+	assert.Equal(t, "1.1.1.1", clientIP(call("10.1.2.3", nil, "6.6.6.6, 1.1.1.1, 10.0.0.9"), proxies))
+	assert.Equal(t, "10.0.0.1", clientIP(call("10.1.2.3", nil, "10.0.0.1"), proxies))
+	assert.Equal(t, "1.1.1.1", forwardedClientIP([]string{"6.6.6.6, 1.1.1.1", "10.0.0.5"}, proxies))
+
+	_, err = parseTrustedProxies([]string{"10.0.0.0"})
+	assert.NotNil(t, err)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -10,19 +11,38 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	_ "github.com/bufbuild/protovalidate-go"
 	_ "github.com/lib/pq"
 
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/dbs/maindb/migrations"
 	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/internal/idempotency"
+	"github.com/Dionid/go-boiler/internal/ratelimitstore"
+	"github.com/Dionid/go-boiler/pkg/app"
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/health"
+	"github.com/Dionid/go-boiler/pkg/lifecycle"
+	"github.com/Dionid/go-boiler/pkg/metrics"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/requestid"
 	"github.com/Dionid/go-boiler/pkg/terrors"
-	"github.com/jmoiron/sqlx"
+	"github.com/Dionid/go-boiler/pkg/tracing"
+	"github.com/pressly/goose/v3"
+	"github.com/spf13/pflag"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 var allowedHeaders = map[string]struct{}{
-	"x-request-id": {},
+	requestid.MetadataKey:      {},
+	ratelimit.HeaderLimit:      {},
+	ratelimit.HeaderRemaining:  {},
+	ratelimit.HeaderReset:      {},
+	ratelimit.HeaderRetryAfter: {},
 }
 
 func isHeaderAllowed(s string) (string, bool) {
@@ -37,11 +57,42 @@ func isHeaderAllowed(s string) (string, bool) {
 
 func main() {
 	// # Config
-	config, err := initConfig()
+	config, args, err := initConfig(os.Args[1:])
+	if err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		log.Fatal(err)
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "config":
+			err = runConfigCommand(config, args)
+		case "migrate":
+			err = runMigrateCommand(context.Background(), config, args)
+		default:
+			log.Fatalf("unknown command: %s", args[0])
+		}
+
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	// # Secrets
+	loaded := *config
+	secretsResolver, jwtSecret, err := resolveSecrets(context.Background(), config)
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	if err := config.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
 	// # Logger
 	zapConfig := zap.NewDevelopmentConfig()
 	if config.Env == "production" {
@@ -53,7 +104,32 @@ func main() {
 		log.Fatal(err)
 	}
 
-	logger.Info("Starting")
+	logger.Info("Starting", zap.Any("config", config.Redacted()))
+
+	terrors.SetStackCapture(config.CaptureErrorStack)
+
+	// # App
+	a := app.New(logger)
+
+	// # Tracing
+	var shutdownTracing func(context.Context) error
+	a.Register(app.Component{
+		Name: "tracing",
+		Start: func(ctx context.Context) (err error) {
+			shutdownTracing, err = tracing.Init(ctx, tracing.Config{
+				ServiceName:  config.TracingServiceName,
+				Exporter:     config.TracingExporter,
+				OtlpEndpoint: config.TracingOtlpEndpoint,
+				OtlpInsecure: config.TracingOtlpInsecure,
+				FilePath:     config.TracingFilePath,
+				SampleRatio:  config.TracingSampleRatio,
+			})
+			return err
+		},
+		Stop: func(ctx context.Context) error {
+			return shutdownTracing(ctx)
+		},
+	})
 
 	// # Graceful shutdown emitter
 	gse := make(chan string, 1)
@@ -64,104 +140,295 @@ func main() {
 	go func() {
 		sig := <-sigs
 		gse <- sig.String()
+
+		// ## Second signal forces exit
+		sig = <-sigs
+		logger.Error(fmt.Sprintf("Received second %s signal, forcing exit", sig))
+		os.Exit(1)
 	}()
 
-	// # Global WaitGroup
+	// # Global WaitGroup for background workers
 	gwg := &sync.WaitGroup{}
 
 	// # Global Context
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// # DB
-	mainPgPool, err := sqlx.Open("postgres", config.MainDbConnectionStr)
+	mainPgPool, err := tracing.OpenPostgres(config.MainDbConnectionStr)
 	if err != nil {
 		log.Fatalf("DB error: %v\n", err)
 	}
-	defer mainPgPool.Close()
 
 	mainPgPool.SetMaxOpenConns(10)
 
+	a.Register(app.Component{
+		Name:      "main db",
+		DependsOn: []string{"tracing"},
+		Start:     mainPgPool.PingContext,
+		Stop: func(ctx context.Context) error {
+			return mainPgPool.Close()
+		},
+	})
+
+	// # Migrations
+	a.Register(app.Component{
+		Name:      "main db migrations",
+		DependsOn: []string{"main db"},
+		Start: func(ctx context.Context) error {
+			if config.MigrateOnStart {
+				err := migrateOnStart(ctx, mainPgPool.DB, func(result *goose.MigrationResult) {
+					logger.Info("Migrated", zap.String("migration", result.String()))
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			// ## Serving old schema breaks features, newer one is fine during rolling update
+			return migrations.CheckNotBehind(ctx, mainPgPool)
+		},
+	})
+
 	// # Init first admin
-	err = initFirstAdmin(ctx, config, mainPgPool)
-	if err != nil {
-		if v, ok := err.(*terrors.PrivateError); ok {
-			log.Fatalf("Init first admin: %v\n", v.GetPrivateMessage())
-		}
-		log.Fatalf("Init first admin: %v\n", err)
-	}
+	a.Register(app.Component{
+		Name:      "first admin",
+		DependsOn: []string{"main db migrations"},
+		Start: func(ctx context.Context) error {
+			if err := initFirstAdmin(ctx, config, mainPgPool); err != nil {
+				return fmt.Errorf("%s: %w", err.GetPrivateMessage(), err)
+			}
+			return nil
+		},
+	})
+
+	// # Metrics
+	metricsRegistry := metrics.NewRegistry(config.MetricsNamespace)
+	metricsRegistry.RegisterDB("main", mainPgPool.DB)
+
+	// # Health
+	healthRegistry := health.NewRegistry(
+		time.Duration(config.HealthCheckTimeoutInSeconds)*time.Second,
+		proto.MainApi_ServiceDesc.ServiceName,
+	)
+	healthRegistry.AddLivenessCheck("app", a.Check)
+	healthRegistry.AddReadinessCheck("main_db", health.PingChecker(mainPgPool))
+	healthRegistry.AddReadinessCheck("main_db_migrations", func(ctx context.Context) error {
+		return migrations.CheckNotBehind(ctx, mainPgPool)
+	})
 
 	// # Deps
 	deps := &features.Deps{
-		Logger: logger,
-		MainDb: mainPgPool,
+		Logger:  logger,
+		MainDb:  mainPgPool,
+		Metrics: metricsRegistry,
+		Health:  healthRegistry,
+		Events:  events.NewBus(config.EventsReplaySize),
 		Config: features.Config{
-			JwtSecret:       []byte(config.JwtSecret),
+			JwtSecretSource: jwtSecret.Bytes,
 			ExpireInSeconds: config.JwtExpireInSeconds,
 		},
+		GlobalCtx:               ctx,
 		GlobalWg:                gwg,
 		GracefulShutdownEmitter: gse,
 	}
 
+	// # Live config
+	live := newLiveConfig(config, &loaded, os.Args[1:], logger, zapConfig.Level, deps)
+	deps.ConfigSnapshot = live.Snapshot
+
+	reloadCtx, stopReload := context.WithCancel(context.Background())
+	a.Register(app.Component{
+		Name: "config reload",
+		Start: func(ctx context.Context) error {
+			a.Go("config reload", func() error { return live.Watch(reloadCtx) })
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			stopReload()
+			return nil
+		},
+	})
+
+	// # Secrets refresh
+	if config.SecretsRefreshIntervalInSeconds > 0 {
+		refreshCtx, stopRefresh := context.WithCancel(context.Background())
+
+		a.Register(app.Component{
+			Name: "secrets refresh",
+			Start: func(ctx context.Context) error {
+				a.Go("secrets refresh", func() error {
+					secretsResolver.Run(refreshCtx, time.Duration(config.SecretsRefreshIntervalInSeconds)*time.Second, func(err error) {
+						logger.Error("Secrets refresh", zap.Error(err))
+					})
+					return nil
+				})
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				stopRefresh()
+				return nil
+			},
+		})
+	}
+
+	// # Idempotency keys cleanup
+	cleanupCtx, stopCleanup := context.WithCancel(context.Background())
+	a.Register(app.Component{
+		Name:      "idempotency cleanup",
+		DependsOn: []string{"main db"},
+		Start: func(ctx context.Context) error {
+			a.Go("idempotency cleanup", func() error {
+				idempotency.Cleanup(cleanupCtx, mainPgPool, time.Duration(config.IdempotencyCleanupIntervalInSeconds)*time.Second, func(err error) {
+					logger.Error("Idempotency keys cleanup", zap.Error(err))
+				})
+				return nil
+			})
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			stopCleanup()
+			return nil
+		},
+	})
+
+	// # Rate limit keys cleanup, memory store forgets them by itself
+	if config.RateLimitStore == "postgres" {
+		rateLimitCleanupCtx, stopRateLimitCleanup := context.WithCancel(context.Background())
+		rateLimitStore := ratelimitstore.NewPostgres(mainPgPool)
+
+		a.Register(app.Component{
+			Name:      "rate limit cleanup",
+			DependsOn: []string{"main db"},
+			Start: func(ctx context.Context) error {
+				a.Go("rate limit cleanup", func() error {
+					rateLimitStore.Cleanup(rateLimitCleanupCtx, time.Minute, func(err error) {
+						logger.Error("Rate limit keys cleanup", zap.Error(err))
+					})
+					return nil
+				})
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				stopRateLimitCleanup()
+				return nil
+			},
+		})
+	}
+
+	// # Background workers
+	a.Register(app.Component{
+		Name:      "workers",
+		DependsOn: []string{"main db"},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			return lifecycle.Wait(ctx, gwg)
+		},
+	})
+
+	// # TLS
+	var certReloader *certs.Reloader
+	if config.TlsCertFile != "" {
+		certReloader, err = certs.NewReloader(config.TlsCertFile, config.TlsKeyFile, config.TlsClientCaFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		tlsReloadCtx, stopTlsReload := context.WithCancel(context.Background())
+		a.Register(app.Component{
+			Name: "tls reload",
+			Start: func(ctx context.Context) error {
+				a.Go("tls reload", func() error {
+					return certReloader.Watch(tlsReloadCtx, func(err error) {
+						if err != nil {
+							logger.Error("TLS certificate reload", zap.Error(err))
+							return
+						}
+						logger.Info("TLS certificate reloaded")
+					})
+				})
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				stopTlsReload()
+				return nil
+			},
+		})
+	}
+
 	// # Server
-	e, serveGrpc, serveCmux, closeServer, err := initServer(ctx, config, logger, deps)
+	serveServer, shutdownServer, err := initServer(ctx, config, live, certReloader, logger, deps)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	gwg.Add(1)
-	go func() {
-		logger.Info(fmt.Sprintf("Starting gRPC Gateway on port %d", config.Port))
-		err := e.Start(fmt.Sprintf(":%d", config.Port))
-		logger.Info(fmt.Sprintf("echo %v", err))
-		if err != nil && !strings.Contains(err.Error(), "server closed") && err != http.ErrServerClosed {
-			logger.Sugar().Errorf("e.Start err: %v\n", err)
-			log.Fatal(err)
-		}
+	a.Register(app.Component{
+		Name:      "server",
+		DependsOn: []string{"main db", "first admin", "workers"},
+		Start: func(ctx context.Context) error {
+			logger.Info(fmt.Sprintf("Starting gRPC Gateway, gRPC Node and combined server on port %d", config.Port), zap.Bool("tls", certReloader != nil))
+			for _, serve := range serveServer {
+				a.Go("server", serve)
+			}
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			// # Mark not ready first, so load balancers stop sending new traffic
+			healthRegistry.Shutdown()
+			if err := lifecycle.Sleep(ctx, time.Duration(config.ShutdownReadinessDelayInSeconds)*time.Second); err != nil {
+				return err
+			}
 
-		gwg.Done()
-	}()
+			// # Stop accepting and drain in-flight HTTP and gRPC calls
+			return shutdownServer(ctx)
+		},
+	})
 
-	gwg.Add(1)
-	go func() {
-		logger.Info(fmt.Sprintf("Starting gRPC Node on port %d", config.Port))
-		err := serveGrpc()
-		logger.Info(fmt.Sprintf("grpc %v", err))
-		if err != nil && !strings.Contains(err.Error(), "server closed") {
-			logger.Sugar().Errorf("serveGrpc err: %v\n", err)
-			log.Fatal(err)
+	// # Metrics server on internal port
+	if config.MetricsPort != 0 {
+		metricsServer := &http.Server{
+			Addr:              fmt.Sprintf(":%d", config.MetricsPort),
+			Handler:           metricsRegistry.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
 		}
 
-		gwg.Done()
-	}()
+		a.Register(app.Component{
+			Name: "metrics server",
+			Start: func(ctx context.Context) error {
+				logger.Info(fmt.Sprintf("Starting metrics server on port %d", config.MetricsPort))
+				a.Go("metrics server", metricsServer.ListenAndServe)
+				return nil
+			},
+			Stop: metricsServer.Shutdown,
+		})
+	}
 
-	gwg.Add(1)
-	go func() {
-		logger.Info(fmt.Sprintf("Starting combined server on port %d", config.Port))
-		err := serveCmux()
-		logger.Info(fmt.Sprintf("cmux: %v", err))
-		if err != nil && !strings.Contains(err.Error(), "closed network connection") {
-			logger.Sugar().Errorf("serveCmux err: %v\n", err)
-			log.Fatal(err)
-		}
+	// # Start
+	if err := a.Start(ctx); err != nil {
+		log.Fatal(err)
+	}
 
-		gwg.Done()
-	}()
+	logger.Info("Started")
 
-	// # Graceful shutdown
-	go func() {
-		reason := <-gse
-		gwg.Add(1)
+	exitCode := 0
+
+	select {
+	case reason := <-gse:
 		logger.Info(fmt.Sprintf("Received %s signal", reason))
-		closeServer()
-		logger.Info("Server closed")
-		mainPgPool.Close()
-		logger.Info("mainPgPool closed")
-		cancel()
-		logger.Info("ctx canceled")
-		gwg.Done()
-	}()
+	case err := <-a.Failed():
+		logger.Error("Component failed, shutting down", zap.Error(err))
+		exitCode = 1
+	}
 
-	logger.Info("Started")
-	gwg.Wait()
-	logger.Info("Bye")
+	// # Graceful shutdown
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), time.Duration(config.ShutdownTimeoutInSeconds)*time.Second)
+	defer shutdownCancel()
+
+	if err := a.Stop(shutdownCtx); err != nil {
+		logger.Error("Graceful shutdown failed", zap.Error(err))
+		exitCode = 1
+	}
+
+	logger.Info("Bye", zap.Any("status", a.Status()))
+	logger.Sync()
+	os.Exit(exitCode)
 }
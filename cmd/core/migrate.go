@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Dionid/go-boiler/dbs/maindb/migrations"
+	"github.com/pressly/goose/v3"
+)
+
+const migrateUsage = "usage: core migrate up | down | status | redo | create <name> [--migrations-dir dbs/maindb/migrations]"
+
+// runMigrateCommand runs `migrate up | down | status | redo | create <name>` on embedded migrations
+func runMigrateCommand(ctx context.Context, config *Config, args []string) error {
+	if len(args) < 2 {
+		return errors.New(migrateUsage)
+	}
+
+	if args[1] == "create" {
+		if len(args) != 3 {
+			return errors.New("usage: core migrate create <name> [--migrations-dir dbs/maindb/migrations]")
+		}
+
+		// # Default dir is relative to repository root
+		if info, err := os.Stat(config.MigrationsDir); err != nil || !info.IsDir() {
+			return fmt.Errorf("migrations dir %s isn't found: run from repository root or pass --migrations-dir", config.MigrationsDir)
+		}
+
+		return migrations.Create(config.MigrationsDir, args[2])
+	}
+
+	if len(args) != 2 {
+		return errors.New(migrateUsage)
+	}
+
+	// # MAIN_DB_PASSWORD can be a secret reference
+	if _, _, err := resolveSecrets(ctx, config); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("postgres", config.MainDbConnectionStr)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	var results []*goose.MigrationResult
+	switch args[1] {
+	case "up":
+		results, err = provider.Up(ctx)
+	case "down":
+		var result *goose.MigrationResult
+		result, err = provider.Down(ctx)
+		if result != nil {
+			results = append(results, result)
+		}
+	case "redo":
+		results, err = migrations.Redo(ctx, provider)
+	case "status":
+		return printMigrationsStatus(ctx, provider)
+	default:
+		return fmt.Errorf("unknown migrate command: %s", args[1])
+	}
+
+	for _, result := range results {
+		fmt.Println(result)
+	}
+
+	return err
+}
+
+func printMigrationsStatus(ctx context.Context, provider *goose.Provider) error {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Applied At\tMigration")
+	for _, status := range statuses {
+		appliedAt := "Pending"
+		if status.State == goose.StateApplied {
+			appliedAt = status.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", appliedAt, status.Source.Path)
+	}
+
+	return w.Flush()
+}
+
+// migrateOnStart applies pending migrations, concurrent replicas wait for advisory lock
+func migrateOnStart(ctx context.Context, db *sql.DB, logger func(result *goose.MigrationResult)) error {
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return err
+	}
+
+	results, err := provider.Up(ctx)
+	for _, result := range results {
+		logger(result)
+	}
+
+	return err
+}
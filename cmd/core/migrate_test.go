@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitMigrateCreate(t *testing.T) {
+	dir := t.TempDir()
+
+	err := runMigrateCommand(context.Background(), &Config{MigrationsDir: dir}, []string{"migrate", "create", "add_orders"})
+	assert.Nil(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*_add_orders.sql"))
+	assert.Nil(t, err)
+	assert.Len(t, files, 1)
+
+	err = runMigrateCommand(context.Background(), &Config{MigrationsDir: filepath.Join(dir, "missing")}, []string{"migrate", "create", "add_orders"})
+	assert.ErrorContains(t, err, "--migrations-dir")
+
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	assert.True(t, os.IsNotExist(err))
+}
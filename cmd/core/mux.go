@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"net"
+
+	"golang.org/x/net/http2"
+)
+
+// # HTTP/2 frames of client, https://www.rfc-editor.org/rfc/rfc9113#section-4.1
+
+const (
+	frameHeaderLen = 9
+	frameHeaders   = 0x1
+	frameSettings  = 0x4
+	frameContinue  = 0x9
+	flagAck        = 0x1
+	flagEndHeaders = 0x4
+)
+
+// settingsAckListener serves HTTP/2 connections that cmux didn't match as gRPC to echo.
+// cmux gRPC matcher answers client SETTINGS before routing, so client ACKs SETTINGS
+// echo HTTP/2 server didn't send and the server would close connection on it.
+type settingsAckListener struct {
+	net.Listener
+}
+
+func (l settingsAckListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return &settingsAckConn{Conn: conn, reader: bufio.NewReader(conn)}, nil
+}
+
+// settingsAckConn drops one client SETTINGS ACK per client SETTINGS sent before the first request headers,
+// those are SETTINGS cmux answered
+type settingsAckConn struct {
+	net.Conn
+	reader *bufio.Reader
+
+	checkedPreface bool
+	passthrough    bool
+	headersDone    bool
+	acksToDrop     int
+	pending        []byte
+}
+
+// NetConn returns underlying connection, so its TLS state can be found
+func (c *settingsAckConn) NetConn() net.Conn {
+	return c.Conn
+}
+
+func (c *settingsAckConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		if c.passthrough {
+			return c.reader.Read(p)
+		}
+
+		if err := c.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// next reads preface or the next frame into pending
+func (c *settingsAckConn) next() error {
+	// # HTTP/1 is passed through as soon as it differs from preface
+	if !c.checkedPreface {
+		for len(c.pending) < len(http2.ClientPreface) {
+			b, err := c.reader.ReadByte()
+			if err != nil {
+				return err
+			}
+			c.pending = append(c.pending, b)
+
+			if b != http2.ClientPreface[len(c.pending)-1] {
+				c.passthrough = true
+				return nil
+			}
+		}
+
+		c.checkedPreface = true
+		return nil
+	}
+
+	frame := make([]byte, frameHeaderLen)
+	if _, err := io.ReadFull(c.reader, frame); err != nil {
+		return err
+	}
+	length := int(frame[0])<<16 | int(frame[1])<<8 | int(frame[2])
+	frameType, flags := frame[3], frame[4]
+
+	frame = append(frame, make([]byte, length)...)
+	if _, err := io.ReadFull(c.reader, frame[frameHeaderLen:]); err != nil {
+		return err
+	}
+
+	switch {
+	case frameType == frameSettings && flags&flagAck != 0 && c.acksToDrop > 0:
+		c.acksToDrop--
+		frame = nil
+	case frameType == frameSettings && flags&flagAck == 0 && !c.headersDone:
+		c.acksToDrop++
+	case (frameType == frameHeaders || frameType == frameContinue) && flags&flagEndHeaders != 0:
+		c.headersDone = true
+	}
+
+	c.pending = frame
+	c.passthrough = c.headersDone && c.acksToDrop == 0
+	return nil
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/secrets"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+// reloadableKeys are applied on reload, changes of other keys require restart
+var reloadableKeys = map[string]bool{
+	"LOG_LEVEL":          true,
+	"CORS_ALLOW_ORIGINS": true,
+	"RATE_LIMIT_RULES":   true,
+	"FEATURE_FLAGS":      true,
+}
+
+const reloadDebounce = 200 * time.Millisecond
+
+// liveConfig holds effective config and swaps its reloadable subset
+// on SIGHUP or config file change
+type liveConfig struct {
+	args     []string
+	logger   *zap.Logger
+	logLevel zap.AtomicLevel
+	deps     *features.Deps
+
+	mu sync.Mutex
+	// loaded is config as read, before secrets are resolved
+	loaded      *Config
+	current     atomic.Pointer[Config]
+	corsOrigins atomic.Pointer[[]string]
+}
+
+func newLiveConfig(config *Config, loaded *Config, args []string, logger *zap.Logger, logLevel zap.AtomicLevel, deps *features.Deps) *liveConfig {
+	l := &liveConfig{
+		loaded:   loaded,
+		args:     args,
+		logger:   logger,
+		logLevel: logLevel,
+		deps:     deps,
+	}
+
+	l.apply(config)
+
+	return l
+}
+
+func (l *liveConfig) apply(config *Config) {
+	l.logLevel.SetLevel(config.EffectiveLogLevel())
+
+	origins := slices.Clone(config.CorsAllowOrigins)
+	l.corsOrigins.Store(&origins)
+
+	featureFlags := make(map[string]bool, len(config.FeatureFlags))
+	for _, flag := range config.FeatureFlags {
+		featureFlags[flag] = true
+	}
+
+	// # Rules are already checked by Validate
+	rateLimitRules, _ := ratelimit.ParseRules(config.RateLimitRules)
+
+	l.deps.SetRuntime(&features.Runtime{
+		FeatureFlags:   featureFlags,
+		RateLimitRules: rateLimitRules,
+	})
+
+	l.current.Store(config)
+}
+
+// Snapshot returns effective config with secrets redacted
+func (l *liveConfig) Snapshot() map[string]any {
+	return l.current.Load().Redacted()
+}
+
+// AllowOrigin is echo CORS AllowOriginFunc
+func (l *liveConfig) AllowOrigin(origin string) (bool, error) {
+	for _, allowed := range *l.corsOrigins.Load() {
+		if allowed == "*" || allowed == origin {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// diffConfig returns "KEY: old -> new" for every changed key
+func diffConfig(current map[string]any, next map[string]any) (changes []string, changedKeys []string) {
+	for key, value := range next {
+		if !reflect.DeepEqual(current[key], value) {
+			changedKeys = append(changedKeys, key)
+		}
+	}
+
+	sort.Strings(changedKeys)
+
+	for _, key := range changedKeys {
+		changes = append(changes, fmt.Sprintf("%s: %v -> %v", key, current[key], next[key]))
+	}
+
+	return changes, changedKeys
+}
+
+// validateUnresolved validates reloaded config without resolving its secret references,
+// referenced DSN is checked by current one, as secrets aren't reloadable
+func validateUnresolved(next *Config, current *Config) error {
+	validated := *next
+	if _, ok := secrets.ParseRef(validated.MainDbConnectionStr); ok {
+		validated.MainDbConnectionStr = current.MainDbConnectionStr
+	}
+
+	return validated.Validate()
+}
+
+// Reload re-reads all config sources, validates them and applies reloadable keys.
+// Invalid config is rejected and current one is kept.
+// Secrets aren't resolved, their changes are compared by references and require restart.
+func (l *liveConfig) Reload(reason string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	current := l.current.Load()
+
+	next, _, err := initConfig(l.args)
+	if err == nil {
+		err = validateUnresolved(next, current)
+	}
+
+	if err != nil {
+		var changes []string
+		if next != nil {
+			changes, _ = diffConfig(l.loaded.Redacted(), next.Redacted())
+		}
+
+		l.logger.Error("Config reload rejected", zap.String("reason", reason), zap.Strings("diff", changes), zap.Error(err))
+		return err
+	}
+
+	changes, changedKeys := diffConfig(l.loaded.Redacted(), next.Redacted())
+	l.loaded = next
+	if len(changes) == 0 {
+		l.logger.Info("Config reload: nothing changed", zap.String("reason", reason))
+		return nil
+	}
+
+	restartRequired := []string{}
+	for _, key := range changedKeys {
+		if !reloadableKeys[key] {
+			restartRequired = append(restartRequired, key)
+		}
+	}
+
+	effective := *current
+	effective.LogLevel = next.LogLevel
+	effective.CorsAllowOrigins = next.CorsAllowOrigins
+	effective.RateLimitRules = next.RateLimitRules
+	effective.FeatureFlags = next.FeatureFlags
+
+	l.apply(&effective)
+
+	l.logger.Info("Config reloaded", zap.String("reason", reason), zap.Strings("diff", changes), zap.Strings("restartRequired", restartRequired))
+
+	return nil
+}
+
+// watchedFiles are config files that exist
+func (l *liveConfig) watchedFiles() []string {
+	files := []string{}
+
+	for _, file := range []string{"app.env", l.current.Load().ConfigFile} {
+		if file == "" {
+			continue
+		}
+
+		if abs, err := filepath.Abs(file); err == nil {
+			if _, err := os.Stat(abs); err == nil {
+				files = append(files, abs)
+			}
+		}
+	}
+
+	return files
+}
+
+// watchFiles watches dirs of files, editors and k8s ConfigMaps replace files instead of writing them
+func watchFiles(files []string) (*fsnotify.Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs := map[string]bool{}
+	for _, file := range files {
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return nil, fmt.Errorf("%s: %w", dir, err)
+		}
+	}
+
+	return watcher, nil
+}
+
+// Watch reloads config on SIGHUP or config file change until ctx is done.
+// When files can't be watched, config is reloaded on SIGHUP only.
+func (l *liveConfig) Watch(ctx context.Context) error {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	// # Nil channels of failed watcher are never ready
+	var events chan fsnotify.Event
+	var errs chan error
+	files := l.watchedFiles()
+	watcher, err := watchFiles(files)
+	if err != nil {
+		l.logger.Error("Config watcher failed, config is reloaded on SIGHUP only", zap.Error(err))
+	} else {
+		defer watcher.Close()
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sighup:
+			l.Reload("SIGHUP")
+		case event := <-events:
+			if slices.Contains(files, event.Name) && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-errs:
+			l.logger.Error("Config watcher", zap.Error(err))
+		case <-debounce.C:
+			l.Reload("file change")
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo-contrib/echoprometheus"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+// # Metrics `url` label of gateway and Connect wildcard routes
+
+// unmatchedRoute labels requests of wildcard routes that no call matched (CORS preflight, rejected encoding, 404)
+const unmatchedRoute = "unmatched"
+
+// gatewayRoute is path pattern of gateway call, gateway sets it only in its own context
+type gatewayRoute struct {
+	pattern string
+}
+
+type gatewayRouteKey struct{}
+
+// gatewayRouteMiddleware passes gatewayRoute to gateway, so recordGatewayRoute can fill it
+func gatewayRouteMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		ctx := context.WithValue(c.Request().Context(), gatewayRouteKey{}, &gatewayRoute{})
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+// recordGatewayRoute is gateway metadata annotator, it runs for matched calls only
+func recordGatewayRoute(ctx context.Context, request *http.Request) metadata.MD {
+	if route, ok := request.Context().Value(gatewayRouteKey{}).(*gatewayRoute); ok {
+		route.pattern, _ = runtime.HTTPPathPattern(ctx)
+	}
+
+	return nil
+}
+
+// metricsUrlLabel labels gateway calls by path pattern and Connect calls by procedure,
+// so raw paths don't make label cardinality unbounded
+func metricsUrlLabel() echoprometheus.LabelValueFunc {
+	procedures := map[string]bool{}
+	for _, method := range proto.MainApi_ServiceDesc.Methods {
+		procedures["/"+proto.MainApi_ServiceDesc.ServiceName+"/"+method.MethodName] = true
+	}
+	for _, stream := range proto.MainApi_ServiceDesc.Streams {
+		procedures["/"+proto.MainApi_ServiceDesc.ServiceName+"/"+stream.StreamName] = true
+	}
+
+	return func(c echo.Context, err error) string {
+		switch c.Path() {
+		case grpcGatewayRoute:
+			if route, ok := c.Request().Context().Value(gatewayRouteKey{}).(*gatewayRoute); ok && route.pattern != "" {
+				return route.pattern
+			}
+			return unmatchedRoute
+		case connectRoute:
+			if procedure := c.Request().URL.Path; procedures[procedure] {
+				return procedure
+			}
+			return unmatchedRoute
+		default:
+			return c.Path()
+		}
+	}
+}
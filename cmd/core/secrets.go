@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pkgconfig "github.com/Dionid/go-boiler/pkg/config"
+	"github.com/Dionid/go-boiler/pkg/secrets"
+)
+
+// resolveSecrets replaces secret references (file://, env://, vault://) in config with values.
+// JWT secret is returned separately to be refreshed by resolver.
+func resolveSecrets(ctx context.Context, config *Config) (*secrets.Resolver, *secrets.Secret, error) {
+	resolver := secrets.NewResolver()
+
+	// # Vault
+	if config.VaultAddress != "" {
+		token, err := resolver.Resolve(ctx, config.VaultToken)
+		if err != nil {
+			return nil, nil, fmt.Errorf("VAULT_TOKEN: %w", err)
+		}
+
+		resolver.Register(secrets.SchemeVault, secrets.NewVaultProvider(config.VaultAddress, token))
+	}
+
+	// # Static secrets
+	var errs []error
+	for _, field := range []struct {
+		key   string
+		value *string
+	}{
+		{"MAIN_DB_CONNECTION_STR", &config.MainDbConnectionStr},
+		{"MAIN_DB_PASSWORD", &config.MainDbPassword},
+		{"FIRST_USER_PASSWORD", &config.FirstUserPassword},
+	} {
+		resolved, err := resolver.Resolve(ctx, *field.value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field.key, err))
+			continue
+		}
+
+		*field.value = resolved
+	}
+
+	// # Refreshed secrets
+	jwtSecret, err := resolver.Secret(ctx, config.JwtSecret)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("JWT_SECRET: %w", err))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, err
+	}
+
+	// # DB password
+	if config.MainDbPassword != "" {
+		dsn, err := pkgconfig.SetDsnPassword(config.MainDbConnectionStr, config.MainDbPassword)
+		if err != nil {
+			return nil, nil, fmt.Errorf("MAIN_DB_CONNECTION_STR: %w", err)
+		}
+
+		config.MainDbConnectionStr = dsn
+	}
+
+	return resolver, jwtSecret, nil
+}
@@ -3,83 +3,258 @@ package main
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/api/v1/go/proto/protoconnect"
 	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
 	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/internal/idempotency"
+	"github.com/Dionid/go-boiler/internal/locales"
+	"github.com/Dionid/go-boiler/internal/ratelimitstore"
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/Dionid/go-boiler/pkg/compress"
+	"github.com/Dionid/go-boiler/pkg/i18n"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/requestid"
 	"github.com/Dionid/go-boiler/pkg/terrors"
-	"github.com/brpaz/echozap"
+	"github.com/Dionid/go-boiler/pkg/tracing"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/logging"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/labstack/echo-contrib/echoprometheus"
 	"github.com/labstack/echo-contrib/pprof"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/soheilhy/cmux"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
+	"golang.org/x/net/http2"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
 )
 
+const grpcGatewayRoute = "/api/v1/*{grpc_gateway}"
+
+const connectRoute = "/" + protoconnect.MainApiName + "/*"
+
+// inProcessBufferSize of gateway to gRPC connection
+const inProcessBufferSize = 1 << 20
+
 func mapError(err error, logger *zap.Logger) terrors.Error {
 	switch v := err.(type) {
 	case terrors.Error:
-		logger.Error(v.GetPrivateMessage())
+		logger.Error(v.GetPrivateMessage(), terrors.ZapFields(v)...)
 		return v
 	default:
-		return terrors.NewPrivateError(v.Error())
+		if tErr, ok := terrors.FromGRPCError(v); ok {
+			logger.Error(tErr.GetPrivateMessage(), terrors.ZapFields(tErr)...)
+			return tErr
+		}
+		return terrors.WrapPrivateError(v, v.Error())
 	}
 }
 
-func initServer(ctx context.Context, config *Config, logger *zap.Logger, deps *features.Deps) (e *echo.Echo, serveGRPC func() error, serveHTTP func() error, Close func(), err error) {
-	e = echo.New()
+func newI18nBundle(config *Config) (*i18n.Bundle, error) {
+	defaultLocale, err := language.Parse(config.DefaultLocale)
+	if err != nil {
+		return nil, fmt.Errorf("DEFAULT_LOCALE: %w", err)
+	}
+
+	bundle := i18n.NewBundle(defaultLocale)
+	if err := bundle.LoadFS(locales.FS, "."); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// dialGateway connects gateway to gRPC server. In-process connection is trusted
+// to forward client identity, loopback dials PORT over TCP like external
+// clients and is kept to benchmark against.
+func dialGateway(config *Config, withTLS bool, inProcessL *bufconn.Listener) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+
+	switch config.GatewayTransport {
+	case "loopback":
+		if withTLS {
+			return nil, fmt.Errorf("GATEWAY_TRANSPORT loopback doesn't support TLS")
+		}
+
+		return grpc.NewClient(fmt.Sprintf("%s:%d", config.Host, config.Port), opts...)
+	default:
+		opts = append(opts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return inProcessL.DialContext(ctx)
+		}))
+
+		return grpc.NewClient("passthrough:///in-process", opts...)
+	}
+}
+
+// initServer builds echo, gRPC and gateway on one port. Returns functions to run
+// in background and shutdown. certReloader enables TLS, nil serves plaintext.
+func initServer(ctx context.Context, config *Config, live *liveConfig, certReloader *certs.Reloader, logger *zap.Logger, deps *features.Deps) (serve []func() error, shutdown func(ctx context.Context) error, err error) {
+	e := echo.New()
+	// # Start is logged by main
+	e.HideBanner = true
+	e.HidePort = true
 
 	pprof.Register(e)
 
 	e.Pre(middleware.RemoveTrailingSlash())
+	e.Pre(httpapi.QueryTokenMiddleware())
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
-	e.Use(echozap.ZapLogger(logger))
+	e.Use(requestid.EchoMiddleware())
+	e.Use(certs.EchoMiddleware())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOriginFunc: live.AllowOrigin,
+		// # gRPC-Web and Connect clients read status and request id from headers
+		ExposeHeaders: []string{
+			requestid.Header, "Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin",
+			"RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+		},
+	}))
+	e.Use(otelecho.Middleware(config.TracingServiceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		return c.Path() == "/metrics" || strings.HasPrefix(c.Path(), "/health/")
+	})))
+	e.Use(zapLoggerMiddleware(logger))
+	e.Use(echoprometheus.NewMiddlewareWithConfig(echoprometheus.MiddlewareConfig{
+		Namespace:                 deps.Metrics.Namespace(),
+		Registerer:                deps.Metrics.Registerer(),
+		DoNotUseRequestPathFor404: true,
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/metrics"
+		},
+		LabelFuncs: map[string]echoprometheus.LabelValueFunc{
+			// # All gateway and Connect routes share one echo route
+			"url": metricsUrlLabel(),
+		},
+	}))
+	e.Use(compress.EchoMiddleware(compress.Config{
+		MinLength:       config.CompressionMinBytes,
+		MaxRequestBytes: config.CompressionMaxRequestBytes,
+		// # Connect and gRPC-Web negotiate compression themselves, WebSocket and SSE aren't buffered
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == connectRoute || c.Path() == "/api/v1/ws" || c.Path() == "/api/v1/events"
+		},
+	}))
+
+	// # I18n
+	i18nBundle, err := newI18nBundle(config)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	e.HTTPErrorHandler = func(err error, c echo.Context) {
-		mapedErr := mapError(err, logger)
+		locale := i18nBundle.Negotiate(c.Request().Header.Get("Accept-Language"))
+		requestLogger := tracing.Logger(c.Request().Context(), logger).With(requestid.ZapFields(c.Request().Context())...)
+		mapedErr := terrors.Localize(mapError(err, requestLogger), i18nBundle.Translator(locale))
 		c.JSON(mapedErr.GetCode(), map[string]interface{}{
-			"message": mapedErr.Error(),
-			"code":    mapedErr.GetCode(),
+			"message":   mapedErr.Error(),
+			"code":      mapedErr.GetCode(),
+			"requestId": requestid.FromContext(c.Request().Context()),
 		})
 	}
 
+	failurePolicy, err := httpapi.ParseFailurePolicy(config.FailurePolicy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// # Rate limiting, rules are reloaded without restart
+	trustedProxies, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+	if config.RateLimitStore == "postgres" {
+		rateLimitStore = ratelimitstore.NewPostgres(deps.MainDb)
+	}
+	rateLimiter := &ratelimit.Limiter{
+		Store: rateLimitStore,
+		Rules: func() []ratelimit.Rule {
+			return deps.Runtime().GetRateLimitRules()
+		},
+		OnError: func(err error) {
+			logger.Error("Rate limit store", zap.Error(err))
+		},
+	}
+
 	// # gRPC
 	grpcServer := grpc.NewServer(
+		grpc.Creds(certs.Credentials()),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainUnaryInterceptor(
+			requestid.UnaryServerInterceptor(),
+			certs.UnaryServerInterceptor(),
 			logging.UnaryServerInterceptor(InterceptorLogger(logger), logging.WithLogOnEvents(logging.StartCall, logging.FinishCall)),
-			recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(grpcPanicRecoveryHandler)),
+			deps.Metrics.UnaryServerInterceptor(),
+			terrors.UnaryServerInterceptor(),
+			httpapi.FailureResultUnaryInterceptor(failurePolicy),
+			localeUnaryInterceptor(i18nBundle),
+			ratelimit.UnaryServerInterceptor(rateLimiter, rateLimitSubject(deps.Config.GetJwtSecret, trustedProxies)),
+			idempotency.UnaryServerInterceptor(
+				deps.MainDb,
+				deps.Config.GetJwtSecret,
+				time.Duration(config.IdempotencyTtlInSeconds)*time.Second,
+				proto.MainApi_SignUp_FullMethodName,
+			),
+			traceMetaUnaryInterceptor(),
+			recovery.UnaryServerInterceptor(recovery.WithRecoveryHandler(grpcPanicRecoveryHandler(logger))),
 		),
 		grpc.ChainStreamInterceptor(
+			requestid.StreamServerInterceptor(),
+			certs.StreamServerInterceptor(),
 			logging.StreamServerInterceptor(InterceptorLogger(logger), logging.WithLogOnEvents(logging.StartCall, logging.FinishCall)),
-			recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(grpcPanicRecoveryHandler)),
+			deps.Metrics.StreamServerInterceptor(),
+			terrors.StreamServerInterceptor(),
+			ratelimit.StreamServerInterceptor(rateLimiter, rateLimitSubject(deps.Config.GetJwtSecret, trustedProxies)),
+			recovery.StreamServerInterceptor(recovery.WithRecoveryHandler(grpcPanicRecoveryHandler(logger))),
 		),
 	)
 	proto.RegisterMainApiServer(grpcServer, &httpapi.MainApiService{Deps: deps})
+	healthpb.RegisterHealthServer(grpcServer, deps.Health.GRPCServer())
 
 	// # gRPC Gateway
-	mux := runtime.NewServeMux(
+	mux := runtime.NewServeMux(append(
+		httpapi.GatewayMarshalerOptions(httpapi.JSONOptions{
+			EmitDefaults:  config.GatewayJsonEmitDefaults,
+			UseProtoNames: config.GatewayJsonUseProtoNames,
+		}),
 		runtime.WithOutgoingHeaderMatcher(isHeaderAllowed),
+		runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+			// # Client identity comes only from verified certificate
+			key, ok := runtime.DefaultHeaderMatcher(key)
+			return key, ok && !certs.IsMetadataKey(key)
+		}),
 		runtime.WithMetadata(func(ctx context.Context, request *http.Request) metadata.MD {
 			header := request.Header.Get("Authorization")
 			// send all the headers received from the client
-			md := metadata.Pairs("auth", header)
-			return md
+			md := metadata.Pairs(
+				"auth", header,
+				requestid.MetadataKey, request.Header.Get(requestid.Header),
+				idempotency.MetadataKey, request.Header.Get(idempotency.Header),
+			)
+			return metadata.Join(md, certs.Metadata(request.Context()))
 		}),
+		runtime.WithMetadata(recordGatewayRoute),
 		runtime.WithErrorHandler(func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, writer http.ResponseWriter, request *http.Request, err error) {
-			mapedErr := mapError(err, logger)
-
-			fmt.Printf("err: %+v\n", err)
+			mapedErr := mapError(err, tracing.Logger(ctx, logger).With(requestid.ZapFields(request.Context())...))
 
 			//creating a new HTTTPStatusError with a custom status, and passing error
 			newError := runtime.HTTPStatusError{
@@ -90,17 +265,28 @@ func initServer(ctx context.Context, config *Config, logger *zap.Logger, deps *f
 			// using default handler to do the rest of heavy lifting of marshaling error and adding headers
 			runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, writer, request, &newError)
 		}),
-	)
-	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)...)
+	inProcessL := bufconn.Listen(inProcessBufferSize)
+	gatewayConn, err := dialGateway(config, certReloader != nil, inProcessL)
+	if err != nil {
+		return nil, nil, err
 	}
-	err = proto.RegisterMainApiHandlerFromEndpoint(ctx, mux, fmt.Sprintf("%s:%d", config.Host, config.Port), opts)
+	err = proto.RegisterMainApiHandler(ctx, mux, gatewayConn)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, err
 	}
 
 	// Creating a normal HTTP server
-	e.GET("/", httpapi.HealthCheck)
+	e.GET("/", httpapi.Readiness(deps.Health))
+
+	// # Health
+	e.GET("/health/live", httpapi.Liveness(deps.Health))
+	e.GET("/health/ready", httpapi.Readiness(deps.Health))
+
+	// # Metrics
+	if config.MetricsPort == 0 {
+		e.GET("/metrics", echo.WrapHandler(deps.Metrics.Handler()))
+	}
 
 	// # Swagger
 	e.GET("/swagger", func(req echo.Context) error {
@@ -111,7 +297,7 @@ func initServer(ctx context.Context, config *Config, logger *zap.Logger, deps *f
 		var result bytes.Buffer
 
 		httpType := "http"
-		if config.Https {
+		if config.Https || certReloader != nil {
 			httpType = "https"
 		}
 
@@ -128,33 +314,92 @@ func initServer(ctx context.Context, config *Config, logger *zap.Logger, deps *f
 	e.File("/openapi/v1/openapi.yaml", fmt.Sprintf("%s%s", config.SwaggerPathPrefix, "/openapi.yaml"))
 
 	// # gRPC Gateway
-	e.Group("/api/v1/*{grpc_gateway}").Any("", echo.WrapHandler(mux))
+	e.Group(grpcGatewayRoute).Any("", echo.WrapHandler(httpapi.NegotiateAccept(mux)), gatewayRouteMiddleware)
+
+	// # Call by name, static route wins over gateway wildcard
+	callHandler := httpapi.NewCallHandler(gatewayConn, proto.File_calls_proto.Services().ByName("MainApi"), i18nBundle)
+	e.POST("/api/v1/call", callHandler.Handle)
+
+	// # Server-Sent Events over SubscribeEvents stream
+	e.GET("/api/v1/events", (&httpapi.EventsHandler{
+		Client:    proto.NewMainApiClient(gatewayConn),
+		Heartbeat: time.Duration(config.EventsHeartbeatInSeconds) * time.Second,
+	}).Handle)
+
+	// # WebSocket calls and server push
+	e.GET("/api/v1/ws", (&httpapi.WebSocketHandler{
+		Calls:     callHandler,
+		Events:    deps.Events,
+		JwtSecret: deps.Config.GetJwtSecret,
+		Config: httpapi.WebSocketConfig{
+			IdleTimeout:   time.Duration(config.WsIdleTimeoutInSeconds) * time.Second,
+			MaxInFlight:   config.WsMaxInFlight,
+			OutboxSize:    config.WsOutboxSize,
+			MaxFrameBytes: config.WsMaxFrameBytes,
+		},
+		Logger: logger,
+	}).Handle, ratelimit.EchoMiddleware(rateLimiter, "WebSocket", webSocketRateLimitSubject(deps.Config.GetJwtSecret, trustedProxies)))
+
+	// # Connect and gRPC-Web, protocol is negotiated by content type
+	_, connectHandler := protoconnect.NewMainApiHandler(&httpapi.MainApiConnectService{
+		Client: proto.NewMainApiClient(gatewayConn),
+	})
+	e.Any(connectRoute, echo.WrapHandler(connectHandler))
 
 	// creating a listener for server
 	l, err := net.Listen("tcp", fmt.Sprintf(":%d", config.Port))
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, err
+	}
+
+	// # TLS is terminated before cmux, ALPN negotiates h2 only with h2-only clients
+	if certReloader != nil {
+		clientAuth, err := certs.ParseClientAuth(config.TlsClientAuth)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		l = tls.NewListener(l, certReloader.ServerConfig(clientAuth))
 	}
 
 	m := cmux.New(l)
 
-	// a different listener for HTTP1
-	httpL := m.Match(cmux.HTTP1Fast())
+	// # gRPC by content type, gRPC clients wait for SETTINGS before sending request
+	grpcL := m.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
 
-	// a different listener for HTTP2 since gRPC uses HTTP2
-	grpcL := m.Match(cmux.HTTP2())
+	// # HTTP/1 and other HTTP/2 (Connect, gRPC-Web, gateway) to echo
+	httpL := m.Match(cmux.Any())
 
-	// start server
-	// passing dummy listener
-	e.Listener = httpL
+	e.Listener = settingsAckListener{httpL}
+	e.Server.ConnContext = certs.ConnContext
 
-	return e,
-		func() error { return grpcServer.Serve(grpcL) },
-		func() error { return m.Serve() },
-		func() {
+	return []func() error{
+			func() error { return e.StartH2CServer(fmt.Sprintf(":%d", config.Port), &http2.Server{}) },
+			func() error { return grpcServer.Serve(grpcL) },
+			func() error { return grpcServer.Serve(certs.TrustedListener(inProcessL)) },
+			func() error { return m.Serve() },
+		},
+		func(ctx context.Context) error {
+			// # Stop accepting new connections
 			m.Close()
-			e.Shutdown(ctx)
-			grpcL.Close()
+
+			// # Drain HTTP first, so gateway calls in flight reach gRPC
+			httpErr := e.Shutdown(ctx)
+
+			// # Drain gRPC
+			grpcStopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(grpcStopped)
+			}()
+
+			select {
+			case <-grpcStopped:
+				return errors.Join(httpErr, gatewayConn.Close())
+			case <-ctx.Done():
+				grpcServer.Stop()
+				return errors.Join(httpErr, gatewayConn.Close(), fmt.Errorf("grpc graceful stop: %w", ctx.Err()))
+			}
 		},
 		nil
 }
@@ -0,0 +1,568 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/api/v1/go/proto/protoconnect"
+	httpapi "github.com/Dionid/go-boiler/cmd/core/http"
+	"github.com/Dionid/go-boiler/features"
+	fsignup "github.com/Dionid/go-boiler/features/sign-up"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/compress"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/health"
+	"github.com/Dionid/go-boiler/pkg/metrics"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func freePort(t testing.TB) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	defer l.Close()
+
+	return l.Addr().(*net.TCPAddr).Port
+}
+
+// startTestServer runs initServer, without deps it has no DB and GetConfig is the only call that works
+func startTestServer(t testing.TB, gatewayTransport string, deps *features.Deps, configure ...func(config *Config)) (address string, adminToken string) {
+	config := &Config{
+		Host:                     "127.0.0.1",
+		Port:                     freePort(t),
+		GatewayTransport:         gatewayTransport,
+		FailurePolicy:            "transport",
+		DefaultLocale:            "en",
+		MetricsNamespace:         "test",
+		TracingServiceName:       "test",
+		CorsAllowOrigins:         []string{"*"},
+		EventsHeartbeatInSeconds: 15,
+		WsIdleTimeoutInSeconds:   60,
+		WsMaxInFlight:            16,
+		WsOutboxSize:             64,
+		WsMaxFrameBytes:          1 << 20,
+		GatewayJsonEmitDefaults:  true,
+		CompressionMinBytes:      1024,
+	}
+	for _, c := range configure {
+		c(config)
+	}
+
+	logger := zap.NewNop()
+	if deps != nil && deps.Logger != nil {
+		logger = deps.Logger
+	}
+	if deps == nil {
+		deps = &features.Deps{
+			Logger: logger,
+			Config: features.Config{
+				JwtSecret:       []byte("secret"),
+				ExpireInSeconds: 10000,
+			},
+		}
+	}
+	deps.Metrics = metrics.NewRegistry(config.MetricsNamespace)
+	deps.Health = health.NewRegistry(time.Second, proto.MainApi_ServiceDesc.ServiceName)
+	deps.Events = events.NewBus(100)
+
+	live := newLiveConfig(config, config, nil, logger, zap.NewAtomicLevel(), deps)
+	deps.ConfigSnapshot = live.Snapshot
+
+	serve, shutdown, err := initServer(context.Background(), config, live, nil, logger, deps)
+	assert.Nil(t, err)
+
+	for _, s := range serve {
+		go s()
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdown(ctx)
+	})
+
+	adminToken, err = auth.CreateToken(deps.Config.GetJwtSecret(), 10000, uuid.New(), "admin")
+	assert.Nil(t, err)
+
+	return fmt.Sprintf("127.0.0.1:%d", config.Port), adminToken
+}
+
+type gatewayResult struct {
+	status    int
+	requestId string
+	body      map[string]any
+}
+
+func callGateway(t testing.TB, client *http.Client, address string, requestId string, token string) gatewayResult {
+	request := map[string]any{"name": "GetConfig", "id": "1"}
+	if token != "" {
+		request["meta"] = map[string]any{"token": token}
+	}
+	body, err := json.Marshal(request)
+	assert.Nil(t, err)
+
+	httpRequest, err := http.NewRequest(http.MethodPost, "http://"+address+"/api/v1/admin/get-config", bytes.NewReader(body))
+	assert.Nil(t, err)
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("X-Request-Id", requestId)
+
+	response, err := client.Do(httpRequest)
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	result := gatewayResult{status: response.StatusCode, requestId: response.Header.Get("X-Request-Id")}
+	assert.Nil(t, json.NewDecoder(response.Body).Decode(&result.body))
+
+	return result
+}
+
+func TestUnitServerEntryPoints(t *testing.T) {
+	for _, transport := range []string{"in-process", "loopback"} {
+		t.Run(transport, func(t *testing.T) {
+			address, adminToken := startTestServer(t, transport, nil)
+
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			assert.Nil(t, err)
+			defer conn.Close()
+			client := proto.NewMainApiClient(conn)
+
+			callGrpc := func(requestId string, token string) (*proto.GetConfigCallResponse, metadata.MD, error) {
+				request := &proto.GetConfigCallRequest{Name: "GetConfig", Id: "1"}
+				if token != "" {
+					request.Meta = &proto.Meta{Token: &token}
+				}
+
+				header := metadata.MD{}
+				ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", requestId)
+				response, err := client.GetConfig(ctx, request, grpc.Header(&header))
+				return response, header, err
+			}
+
+			t.Run("error passes same interceptors", func(t *testing.T) {
+				_, header, err := callGrpc("grpc-1", "")
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+				assert.Equal(t, []string{"grpc-1"}, header.Get("x-request-id"))
+
+				result := callGateway(t, http.DefaultClient, address, "gateway-1", "")
+				assert.Equal(t, http.StatusUnauthorized, result.status)
+				assert.Equal(t, "gateway-1", result.requestId)
+				assert.Contains(t, result.body["message"], status.Convert(err).Message())
+			})
+
+			t.Run("success is same", func(t *testing.T) {
+				response, _, err := callGrpc("grpc-2", adminToken)
+				assert.Nil(t, err)
+
+				result := callGateway(t, http.DefaultClient, address, "gateway-2", adminToken)
+				assert.Equal(t, http.StatusOK, result.status)
+
+				config := result.body["result"].(map[string]any)["success"].(map[string]any)["config"]
+				assert.Equal(t, response.Result.GetSuccess().Config.AsMap(), config)
+				assert.Equal(t, transport, response.Result.GetSuccess().Config.AsMap()["GATEWAY_TRANSPORT"])
+			})
+
+			t.Run("gateway over h2c", func(t *testing.T) {
+				client := h2cClient()
+				for _, requestId := range []string{"h2c-1", "h2c-2"} {
+					result := callGateway(t, client, address, requestId, adminToken)
+					assert.Equal(t, http.StatusOK, result.status)
+					assert.Equal(t, requestId, result.requestId)
+				}
+			})
+
+			for name, opts := range map[string][]connect.ClientOption{
+				"connect":      nil,
+				"grpc-web":     {connect.WithGRPCWeb()},
+				"connect h2c":  nil,
+				"grpc-web h2c": {connect.WithGRPCWeb()},
+			} {
+				t.Run(name+" passes same interceptors", func(t *testing.T) {
+					httpClient := http.DefaultClient
+					if strings.HasSuffix(name, "h2c") {
+						httpClient = h2cClient()
+					}
+					client := protoconnect.NewMainApiClient(httpClient, "http://"+address, opts...)
+
+					request := connect.NewRequest(&proto.GetConfigCallRequest{Name: "GetConfig", Id: "1"})
+					request.Header().Set("X-Request-Id", name+"-1")
+					_, err := client.GetConfig(context.Background(), request)
+					assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+					var connectErr *connect.Error
+					assert.ErrorAs(t, err, &connectErr)
+					assert.Equal(t, name+"-1", connectErr.Meta().Get("X-Request-Id"))
+
+					request = connect.NewRequest(&proto.GetConfigCallRequest{Name: "GetConfig", Id: "1", Meta: &proto.Meta{Token: &adminToken}})
+					request.Header().Set("X-Request-Id", name+"-2")
+					response, err := client.GetConfig(context.Background(), request)
+					assert.Nil(t, err)
+					assert.Equal(t, name+"-2", response.Header().Get("X-Request-Id"))
+					assert.Equal(t, transport, response.Msg.Result.GetSuccess().Config.AsMap()["GATEWAY_TRANSPORT"])
+				})
+			}
+		})
+	}
+}
+
+// h2cClient speaks HTTP/2 without TLS, its calls share one connection
+func h2cClient() *http.Client {
+	return &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, addr)
+		},
+	}}
+}
+
+// BenchmarkGateway compares gateway to gRPC hop: make bench-gateway
+func BenchmarkGateway(b *testing.B) {
+	for _, transport := range []string{"in-process", "loopback"} {
+		b.Run(transport, func(b *testing.B) {
+			address, adminToken := startTestServer(b, transport, nil)
+			client := &http.Client{Transport: &http.Transport{MaxIdleConnsPerHost: 100}}
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if result := callGateway(b, client, address, "bench", adminToken); result.status != http.StatusOK {
+						b.Fatalf("status %d", result.status)
+					}
+				}
+			})
+		})
+	}
+}
+
+func TestUnitServerEvents(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	deps := &features.Deps{
+		Logger: zap.New(core),
+		Config: features.Config{
+			JwtSecret:       []byte("secret"),
+			ExpireInSeconds: 10000,
+		},
+	}
+	address, adminToken := startTestServer(t, "in-process", deps)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Run("grpc stream", func(t *testing.T) {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		stream, err := proto.NewMainApiClient(conn).SubscribeEvents(ctx, &proto.SubscribeEventsCallRequest{
+			Name:   "SubscribeEvents",
+			Id:     "1",
+			Meta:   &proto.Meta{Token: &adminToken},
+			Params: &proto.SubscribeEventsCallRequest_Params{Topics: []string{fsignup.EventSignedUp}},
+		})
+		assert.Nil(t, err)
+		_, err = stream.Header()
+		assert.Nil(t, err)
+
+		deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "a@b.c"})
+
+		response, err := stream.Recv()
+		assert.Nil(t, err)
+		assert.Equal(t, "1", response.Id)
+		assert.Equal(t, "a@b.c", response.Result.GetSuccess().Data.GetStructValue().Fields["email"].GetStringValue())
+	})
+
+	t.Run("connect stream", func(t *testing.T) {
+		client := protoconnect.NewMainApiClient(http.DefaultClient, "http://"+address)
+
+		stream, err := client.SubscribeEvents(ctx, connect.NewRequest(&proto.SubscribeEventsCallRequest{
+			Name:   "SubscribeEvents",
+			Meta:   &proto.Meta{Token: &adminToken},
+			Params: &proto.SubscribeEventsCallRequest_Params{Topics: []string{fsignup.EventSignedUp}},
+		}))
+		assert.Nil(t, err)
+		defer stream.Close()
+
+		deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "c@d.e"})
+
+		assert.True(t, stream.Receive())
+		assert.Equal(t, "c@d.e", stream.Msg().Result.GetSuccess().Data.GetStructValue().Fields["email"].GetStringValue())
+	})
+
+	t.Run("sse resumes by last event id", func(t *testing.T) {
+		lastEventId := deps.Events.LastId()
+		deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "e@f.g"})
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+address+"/api/v1/events?topics="+fsignup.EventSignedUp, nil)
+		assert.Nil(t, err)
+		request.Header.Set("Authorization", "Bearer "+adminToken)
+		request.Header.Set("Last-Event-ID", fmt.Sprint(lastEventId))
+
+		response, err := http.DefaultClient.Do(request)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/event-stream", response.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(response.Body)
+		lines := []string{}
+		for len(lines) < 3 {
+			line, err := reader.ReadString('\n')
+			assert.Nil(t, err)
+			lines = append(lines, strings.TrimSpace(line))
+		}
+		assert.Equal(t, []string{
+			fmt.Sprintf("id: %d", lastEventId+1),
+			"event: " + fsignup.EventSignedUp,
+			`data: {"email":"e@f.g"}`,
+		}, lines)
+	})
+
+	t.Run("sse query token isn't logged", func(t *testing.T) {
+		// # lastEventId tells this request from other SSE requests in logs
+		query := fmt.Sprintf("lastEventId=%d&topics=%s", deps.Events.LastId(), fsignup.EventSignedUp)
+		response, err := http.Get("http://" + address + "/api/v1/events?" + query + "&token=" + adminToken)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		response.Body.Close()
+
+		// # Request is logged when stream ends
+		uri := "GET /api/v1/events?" + query
+		assert.Eventually(t, func() bool {
+			return logs.FilterField(zap.String("request", uri)).Len() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		for _, entry := range logs.FilterFieldKey("request").All() {
+			assert.NotContains(t, entry.ContextMap()["request"], adminToken)
+		}
+	})
+
+	t.Run("sse rejects unauthorized", func(t *testing.T) {
+		response, err := http.Get("http://" + address + "/api/v1/events?topics=" + fsignup.EventSignedUp)
+		assert.Nil(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+}
+
+func TestUnitServerRateLimit(t *testing.T) {
+	deps := &features.Deps{
+		Logger: zap.NewNop(),
+		Config: features.Config{
+			JwtSecret:       []byte("secret"),
+			ExpireInSeconds: 10000,
+		},
+	}
+	address, adminToken := startTestServer(t, "in-process", deps)
+
+	rules, err := ratelimit.ParseRules([]string{"GetConfig:user:token-bucket:1/1h"})
+	assert.Nil(t, err)
+	deps.SetRuntime(&features.Runtime{RateLimitRules: rules})
+
+	t.Run("gateway sends headers and 429", func(t *testing.T) {
+		call := func() *http.Response {
+			body := fmt.Sprintf(`{"name": "GetConfig", "id": "1", "meta": {"token": "%s"}}`, adminToken)
+			response, err := http.Post("http://"+address+"/api/v1/admin/get-config", "application/json", strings.NewReader(body))
+			assert.Nil(t, err)
+			response.Body.Close()
+			return response
+		}
+
+		response := call()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "1", response.Header.Get("RateLimit-Limit"))
+		assert.Equal(t, "0", response.Header.Get("RateLimit-Remaining"))
+
+		response = call()
+		assert.Equal(t, http.StatusTooManyRequests, response.StatusCode)
+		assert.Equal(t, "3600", response.Header.Get("Retry-After"))
+	})
+
+	t.Run("gateway behind proxy is limited by client ip", func(t *testing.T) {
+		deps := &features.Deps{
+			Logger: zap.NewNop(),
+			Config: features.Config{JwtSecret: []byte("secret"), ExpireInSeconds: 10000},
+		}
+		// # Test client is the proxy
+		address, _ := startTestServer(t, "in-process", deps, func(config *Config) {
+			config.TrustedProxies = []string{"127.0.0.1/32"}
+		})
+
+		rules, err := ratelimit.ParseRules([]string{"GetConfig:ip:token-bucket:1/1h"})
+		assert.Nil(t, err)
+		deps.SetRuntime(&features.Runtime{RateLimitRules: rules})
+
+		call := func(forwardedFor string) int {
+			request, err := http.NewRequest(http.MethodPost, "http://"+address+"/api/v1/admin/get-config", strings.NewReader(`{"name": "GetConfig", "id": "1"}`))
+			assert.Nil(t, err)
+			request.Header.Set("X-Forwarded-For", forwardedFor)
+			response, err := http.DefaultClient.Do(request)
+			assert.Nil(t, err)
+			response.Body.Close()
+			return response.StatusCode
+		}
+
+		assert.NotEqual(t, http.StatusTooManyRequests, call("1.1.1.1"))
+		assert.NotEqual(t, http.StatusTooManyRequests, call("2.2.2.2"))
+		assert.Equal(t, http.StatusTooManyRequests, call("1.1.1.1"))
+	})
+
+	t.Run("grpc is limited by user", func(t *testing.T) {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		token, err := auth.CreateToken(deps.Config.GetJwtSecret(), 10000, uuid.New(), "admin")
+		assert.Nil(t, err)
+
+		client := proto.NewMainApiClient(conn)
+		request := &proto.GetConfigCallRequest{Name: "GetConfig", Id: "1", Meta: &proto.Meta{Token: &token}}
+
+		_, err = client.GetConfig(context.Background(), request)
+		assert.Nil(t, err)
+
+		header := metadata.MD{}
+		_, err = client.GetConfig(context.Background(), request, grpc.Header(&header))
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Equal(t, []string{"3600"}, header.Get(ratelimit.HeaderRetryAfter))
+	})
+}
+
+func TestUnitServerNegotiation(t *testing.T) {
+	address, adminToken := startTestServer(t, "in-process", nil)
+	body := fmt.Sprintf(`{"name": "GetConfig", "id": "1", "meta": {"token": "%s"}}`, adminToken)
+	// # Transport must not decompress to check encoding
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	call := func(header http.Header, body io.Reader) *http.Response {
+		request, err := http.NewRequest(http.MethodPost, "http://"+address+"/api/v1/admin/get-config", body)
+		assert.Nil(t, err)
+		request.Header = header
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := client.Do(request)
+		assert.Nil(t, err)
+		t.Cleanup(func() { response.Body.Close() })
+		return response
+	}
+
+	t.Run("protobuf by accept", func(t *testing.T) {
+		response := call(http.Header{"Accept": {"text/html, application/x-protobuf;q=0.9"}}, strings.NewReader(body))
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, httpapi.MIMEProtobuf, response.Header.Get("Content-Type"))
+
+		data, err := io.ReadAll(response.Body)
+		assert.Nil(t, err)
+		result := &proto.GetConfigCallResponse{}
+		assert.Nil(t, protobuf.Unmarshal(data, result))
+		assert.Equal(t, "in-process", result.Result.GetSuccess().Config.AsMap()["GATEWAY_TRANSPORT"])
+	})
+
+	t.Run("unknown accept falls back to json", func(t *testing.T) {
+		response := call(http.Header{"Accept": {"text/html"}}, strings.NewReader(body))
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	})
+
+	t.Run("gzip request and zstd response", func(t *testing.T) {
+		compressed := &bytes.Buffer{}
+		writer := gzip.NewWriter(compressed)
+		writer.Write([]byte(body))
+		writer.Close()
+
+		response := call(http.Header{"Content-Encoding": {"gzip"}, "Accept-Encoding": {"gzip, zstd"}}, compressed)
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, compress.EncodingZstd, response.Header.Get("Content-Encoding"))
+
+		reader, err := zstd.NewReader(response.Body)
+		assert.Nil(t, err)
+		defer reader.Close()
+		result := map[string]any{}
+		assert.Nil(t, json.NewDecoder(reader).Decode(&result))
+		assert.NotNil(t, result["result"])
+	})
+
+	t.Run("small response isn't compressed", func(t *testing.T) {
+		response := call(http.Header{"Accept-Encoding": {"gzip"}}, strings.NewReader(`{"name": "GetConfig", "id": "1"}`))
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+		assert.Equal(t, "", response.Header.Get("Content-Encoding"))
+	})
+
+	for _, compressor := range []string{compress.EncodingGzip, compress.EncodingZstd} {
+		t.Run("grpc "+compressor, func(t *testing.T) {
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			assert.Nil(t, err)
+			defer conn.Close()
+
+			request := &proto.GetConfigCallRequest{Name: "GetConfig", Id: "1", Meta: &proto.Meta{Token: &adminToken}}
+			response, err := proto.NewMainApiClient(conn).GetConfig(context.Background(), request, grpc.UseCompressor(compressor))
+			assert.Nil(t, err)
+			assert.Equal(t, "in-process", response.Result.GetSuccess().Config.AsMap()["GATEWAY_TRANSPORT"])
+		})
+	}
+}
+
+func TestUnitServerMetricsUrl(t *testing.T) {
+	address, adminToken := startTestServer(t, "in-process", nil)
+
+	callGateway(t, http.DefaultClient, address, "metrics-1", adminToken)
+
+	client := protoconnect.NewMainApiClient(http.DefaultClient, "http://"+address)
+	_, err := client.GetConfig(context.Background(), connect.NewRequest(&proto.GetConfigCallRequest{Name: "GetConfig", Id: "1"}))
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+
+	// # Random paths of wildcard routes that no call matched
+	for path, status := range map[string]int{
+		"/api/v1/random-1":                          http.StatusUnsupportedMediaType,
+		"/" + protoconnect.MainApiName + "/Random1": http.StatusNotFound,
+	} {
+		request, err := http.NewRequest(http.MethodPost, "http://"+address+path, strings.NewReader("{}"))
+		assert.Nil(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Content-Encoding", "unknown")
+
+		response, err := http.DefaultClient.Do(request)
+		assert.Nil(t, err)
+		response.Body.Close()
+		assert.Equal(t, status, response.StatusCode)
+	}
+
+	response, err := http.Get("http://" + address + "/metrics")
+	assert.Nil(t, err)
+	defer response.Body.Close()
+
+	urls := map[string]bool{}
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		if _, rest, ok := strings.Cut(scanner.Text(), `url="`); ok && strings.HasPrefix(scanner.Text(), "test_echo_requests_total") {
+			url, _, _ := strings.Cut(rest, `"`)
+			urls[url] = true
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"/api/v1/admin/get-config":             true,
+		protoconnect.MainApiGetConfigProcedure: true,
+		unmatchedRoute:                         true,
+	}, urls)
+}
@@ -2,11 +2,15 @@ package maindb
 
 type TablesSt struct {
 	GooseDbVersion string `json:"goose_db_version" db:"goose_db_version"`
+	IdempotencyKey string `json:"idempotency_key" db:"idempotency_key"`
+	RateLimit      string `json:"rate_limit" db:"rate_limit"`
 	User           string `json:"user" db:"user"`
 }
 
 var Tables = TablesSt{
 	GooseDbVersion: "goose_db_version",
+	IdempotencyKey: "idempotency_key",
+	RateLimit:      "rate_limit",
 	User:           "user",
 }
 
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE rate_limit (
+    key VARCHAR(512) NOT NULL PRIMARY KEY,
+    value DOUBLE PRECISION NOT NULL,
+    previous DOUBLE PRECISION NOT NULL,
+    at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX rate_limit_expires_at_idx ON rate_limit (expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE rate_limit;
+-- +goose StatementEnd
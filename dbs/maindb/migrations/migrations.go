@@ -0,0 +1,126 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/Dionid/go-boiler/dbs/maindb"
+	"github.com/Dionid/sqli"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// Dir is migrations directory relative to repository root, new migrations are created there
+const Dir = "dbs/maindb/migrations"
+
+//go:embed *.sql
+var FS embed.FS
+
+// NewProvider runs embedded migrations, every command holds Postgres advisory lock,
+// so replicas migrating on start don't race
+func NewProvider(db *sql.DB, opts ...goose.ProviderOption) (*goose.Provider, error) {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return nil, err
+	}
+
+	return goose.NewProvider(goose.DialectPostgres, db, FS, append([]goose.ProviderOption{goose.WithSessionLocker(locker)}, opts...)...)
+}
+
+// Redo rolls back and applies again the latest migration
+func Redo(ctx context.Context, provider *goose.Provider) ([]*goose.MigrationResult, error) {
+	down, err := provider.Down(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	up, err := provider.UpByOne(ctx)
+	if err != nil {
+		return []*goose.MigrationResult{down}, err
+	}
+
+	return []*goose.MigrationResult{down, up}, nil
+}
+
+// Create writes new blank SQL migration to dir
+func Create(dir string, name string) error {
+	return goose.Create(nil, dir, name, "sql")
+}
+
+// LatestVersion returns version of the newest embedded migration
+func LatestVersion() (int64, error) {
+	files, err := fs.Glob(FS, "*.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest int64
+	for _, file := range files {
+		prefix, _, found := strings.Cut(file, "_")
+		if !found {
+			return 0, fmt.Errorf("migration %s has no version prefix", file)
+		}
+
+		version, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %s: %w", file, err)
+		}
+
+		latest = max(latest, version)
+	}
+
+	return latest, nil
+}
+
+// AppliedVersion returns version of the newest migration applied to db
+func AppliedVersion(ctx context.Context, db maindb.DB) (int64, error) {
+	query, err := sqli.Query(
+		sqli.SELECT(
+			maindb.GooseDbVersion.VersionID,
+		),
+		sqli.FROM(maindb.GooseDbVersion),
+		sqli.WHERE(
+			sqli.EQUAL(maindb.GooseDbVersion.IsApplied, true),
+		),
+		sqli.ORDER_BY(
+			sqli.NewColumnOrder(maindb.GooseDbVersion, maindb.GooseDbVersion.VersionID, sqli.DESC),
+		),
+		sqli.LIMIT(1),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var version int64
+	err = db.QueryRowxContext(ctx, query.SQL, query.Args...).Scan(&version)
+	if err != nil {
+		return 0, err
+	}
+
+	return version, nil
+}
+
+// CheckNotBehind returns error if db misses embedded migrations.
+// Newer schema is fine: it's migrated by next version during rolling update
+func CheckNotBehind(ctx context.Context, db maindb.DB) error {
+	latest, err := LatestVersion()
+	if err != nil {
+		return err
+	}
+
+	applied, err := AppliedVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+
+	if applied < latest {
+		return fmt.Errorf("db is migrated to %d, expected at least %d: run `core migrate up` or start with --migrate-on-start", applied, latest)
+	}
+
+	return nil
+}
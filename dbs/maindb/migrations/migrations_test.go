@@ -0,0 +1,100 @@
+package migrations_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Dionid/go-boiler/dbs/maindb/migrations"
+	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitMigrations(t *testing.T) {
+	t.Run("latest version", func(t *testing.T) {
+		latest, err := migrations.LatestVersion()
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, latest, int64(20261019130000))
+	})
+
+	t.Run("create", func(t *testing.T) {
+		dir := t.TempDir()
+		assert.Nil(t, migrations.Create(dir, "add orders"))
+
+		files, err := filepath.Glob(filepath.Join(dir, "*_add_orders.sql"))
+		assert.Nil(t, err)
+		assert.Len(t, files, 1)
+
+		content, err := os.ReadFile(files[0])
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "-- +goose Up"))
+	})
+}
+
+func TestIntMigrations(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testDeps, err := inttests.InitTestDeps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		err := testDeps.Cleanup()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	db := testDeps.MainDbConnection
+
+	provider, err := migrations.NewProvider(db.DB)
+	assert.Nil(t, err)
+
+	t.Run("redo keeps schema migrated", func(t *testing.T) {
+		results, err := migrations.Redo(ctx, provider)
+		assert.Nil(t, err)
+		assert.Len(t, results, 2)
+		assert.Nil(t, migrations.CheckNotBehind(ctx, db))
+	})
+
+	t.Run("down is behind, concurrent up applies once", func(t *testing.T) {
+		_, err := provider.Down(ctx)
+		assert.Nil(t, err)
+		assert.NotNil(t, migrations.CheckNotBehind(ctx, db))
+
+		applied := make(chan int, 2)
+		wg := sync.WaitGroup{}
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				replica, err := migrations.NewProvider(db.DB)
+				assert.Nil(t, err)
+				results, err := replica.Up(ctx)
+				assert.Nil(t, err)
+				applied <- len(results)
+			}()
+		}
+		wg.Wait()
+		close(applied)
+
+		total := 0
+		for n := range applied {
+			total += n
+		}
+		assert.Equal(t, 1, total)
+
+		statuses, err := provider.Status(ctx)
+		assert.Nil(t, err)
+		for _, status := range statuses {
+			assert.Equal(t, goose.StateApplied, status.State)
+		}
+		assert.Nil(t, migrations.CheckNotBehind(ctx, db))
+	})
+}
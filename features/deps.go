@@ -1,23 +1,104 @@
 package features
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/health"
+	"github.com/Dionid/go-boiler/pkg/metrics"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/requestid"
+	"github.com/Dionid/go-boiler/pkg/tracing"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 type Config struct {
-	JwtSecret       []byte
+	// JwtSecret is used when JwtSecretSource is nil
+	JwtSecret []byte
+	// JwtSecretSource returns current secret, so rotated one is picked up without restart
+	JwtSecretSource func() []byte
 	ExpireInSeconds int64
 }
 
+func (c Config) GetJwtSecret() []byte {
+	if c.JwtSecretSource != nil {
+		return c.JwtSecretSource()
+	}
+
+	return c.JwtSecret
+}
+
 type Deps struct {
-	Logger                  *zap.Logger
-	GlobalWg                *sync.WaitGroup
+	// Logger has no ids of the call, features log by Log(ctx)
+	Logger *zap.Logger
+	// GlobalCtx is canceled after servers are drained, background workers must stop on it
+	GlobalCtx context.Context
+	// GlobalWg tracks background workers, shutdown waits for it before closing DB
+	GlobalWg *sync.WaitGroup
+	// GracefulShutdownEmitter starts graceful shutdown with the reason
 	GracefulShutdownEmitter chan string
 
 	MainDb *sqlx.DB
 
+	// Metrics is used by features to declare their own counters / histograms
+	Metrics *metrics.Registry
+
+	// Health is used by features to add their own readiness checks
+	Health *health.Registry
+
+	// Events is used by features to push events to subscribed clients
+	Events *events.Bus
+
 	Config Config
+	// ConfigSnapshot returns effective app config with secrets redacted
+	ConfigSnapshot func() map[string]any
+
+	runtime atomic.Pointer[Runtime]
+}
+
+// Runtime is the part of config reloaded without restart
+type Runtime struct {
+	FeatureFlags   map[string]bool
+	RateLimitRules []ratelimit.Rule
+}
+
+// Enabled reports if feature flag is on
+func (r *Runtime) Enabled(flag string) bool {
+	if r == nil {
+		return false
+	}
+
+	return r.FeatureFlags[flag]
+}
+
+// GetRateLimitRules returns current rate limit rules, nil runtime has none
+func (r *Runtime) GetRateLimitRules() []ratelimit.Rule {
+	if r == nil {
+		return nil
+	}
+
+	return r.RateLimitRules
+}
+
+// Log returns Logger with trace, span and request ids of ctx, features log through it
+func (deps *Deps) Log(ctx context.Context) *zap.Logger {
+	logger := deps.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return tracing.Logger(ctx, logger).With(requestid.ZapFields(ctx)...)
+}
+
+// Runtime returns current runtime config, nil until first SetRuntime
+func (deps *Deps) Runtime() *Runtime {
+	return deps.runtime.Load()
+}
+
+// SetRuntime atomically swaps runtime config
+func (deps *Deps) SetRuntime(runtime *Runtime) {
+	deps.runtime.Store(runtime)
 }
@@ -0,0 +1,41 @@
+package features_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/pkg/requestid"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestUnitDepsLog(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	deps := &features.Deps{Logger: zap.New(core)}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+	ctx = requestid.WithRequestId(ctx, "request-1")
+
+	deps.Log(ctx).Info("in span")
+	deps.Log(context.Background()).Info("outside of span")
+
+	entries := logs.All()
+	assert.Equal(t, map[string]any{
+		"traceId":   spanContext.TraceID().String(),
+		"spanId":    spanContext.SpanID().String(),
+		"requestId": "request-1",
+	}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
+
+	// # Deps without Logger don't log
+	(&features.Deps{}).Log(ctx).Info("nop")
+}
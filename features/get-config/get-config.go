@@ -0,0 +1,55 @@
+package fgetconfig
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func GetConfig(ctx context.Context, deps *features.Deps, request *proto.GetConfigCallRequest) (*proto.GetConfigCallResponse, terrors.Error) {
+	// # Only admins
+	if err := auth.AuthorizeByRoles(deps.Config.GetJwtSecret(), []string{"admin"}, request); err != nil {
+		if tErr, ok := err.(terrors.Error); ok {
+			return nil, tErr
+		}
+		return nil, terrors.WrapPrivateError(err, "in authorize")
+	}
+
+	if deps.ConfigSnapshot == nil {
+		return nil, terrors.NewPrivateError("config snapshot is not set")
+	}
+
+	// # Round trip through JSON to get structpb compatible values
+	data, err := json.Marshal(deps.ConfigSnapshot())
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in marshal config")
+	}
+
+	snapshot := map[string]any{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, terrors.WrapPrivateError(err, "in unmarshal config")
+	}
+
+	config, err := structpb.NewStruct(snapshot)
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in config struct")
+	}
+
+	resp := &proto.GetConfigCallResponse{
+		Id: request.Id,
+		Result: &proto.GetConfigCallResponse_Result{
+			Result: &proto.GetConfigCallResponse_Result_Success_{
+				Success: &proto.GetConfigCallResponse_Result_Success{
+					Config: config,
+				},
+			},
+		},
+	}
+
+	return resp, nil
+}
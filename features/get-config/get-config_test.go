@@ -0,0 +1,57 @@
+package fgetconfig_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/features"
+	fgetconfig "github.com/Dionid/go-boiler/features/get-config"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitGetConfig(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &features.Deps{
+		Config: features.Config{
+			JwtSecret:       []byte("secret"),
+			ExpireInSeconds: 10000,
+		},
+		ConfigSnapshot: func() map[string]any {
+			return map[string]any{
+				"PORT":               8080,
+				"JWT_SECRET":         "******",
+				"CORS_ALLOW_ORIGINS": []string{"*"},
+			}
+		},
+	}
+
+	request := func(role string) *proto.GetConfigCallRequest {
+		token, err := auth.CreateToken(deps.Config.GetJwtSecret(), 10000, uuid.New(), role)
+		assert.Nil(t, err)
+
+		return &proto.GetConfigCallRequest{
+			Name: "GetConfig",
+			Id:   uuid.New().String(),
+			Meta: &proto.Meta{Token: &token},
+		}
+	}
+
+	t.Run("admin", func(t *testing.T) {
+		resp, err := fgetconfig.GetConfig(ctx, deps, request("admin"))
+		assert.Nil(t, err)
+
+		config := resp.Result.GetSuccess().Config.AsMap()
+		assert.Equal(t, float64(8080), config["PORT"])
+		assert.Equal(t, "******", config["JWT_SECRET"])
+		assert.Equal(t, []any{"*"}, config["CORS_ALLOW_ORIGINS"])
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		_, err := fgetconfig.GetConfig(ctx, deps, request("client"))
+		assert.Equal(t, 403, err.GetCode())
+	})
+}
@@ -14,31 +14,31 @@ import (
 func SignIn(ctx context.Context, deps *features.Deps, request *proto.SignInCallRequest) (*proto.SignInCallResponse, terrors.Error) {
 	// # Validate request
 	if request.Params.Email == "" {
-		return nil, terrors.NewValidationError("NewValidationError", nil)
+		return nil, terrors.NewValidationError("email is required", nil).WithReason("validation.required", map[string]any{"field": "email"})
 	}
 
 	if request.Params.Password == "" {
-		return nil, terrors.NewValidationError("NewValidationError", nil)
+		return nil, terrors.NewValidationError("password is required", nil).WithReason("validation.required", map[string]any{"field": "password"})
 	}
 
 	// # Query user
-	user, err := maindb.SelectUserByEmail(ctx, deps.MainDb, request.Params.Email)
-	if err != nil {
-		return nil, terrors.NewPrivateError("Failed to query user")
+	user, err := maindb.SelectUserByEmail(ctx, deps.DB(ctx), request.Params.Email)
+	if err != nil && !terrors.IsNotFoundErr(err) {
+		return nil, terrors.WrapPrivateError(err, "Failed to query user")
 	}
 	if user == nil {
-		return nil, terrors.NewValidationError("Incorrect email or password", nil)
+		return nil, terrors.NewValidationError("Incorrect email or password", nil).WithReason("auth.invalid_credentials", nil)
 	}
 
 	// # Hash and compare password
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(request.Params.Password))
 	if err != nil {
-		return nil, terrors.NewValidationError("Incorrect email or password", nil)
+		return nil, terrors.NewValidationError("Incorrect email or password", nil).WithReason("auth.invalid_credentials", nil)
 	}
 
-	tokenString, err := auth.CreateToken(deps.Config.JwtSecret, deps.Config.ExpireInSeconds, user.ID, user.Role)
+	tokenString, err := auth.CreateToken(deps.Config.GetJwtSecret(), deps.Config.ExpireInSeconds, user.ID, user.Role)
 	if err != nil {
-		return nil, terrors.NewPrivateError("in create token")
+		return nil, terrors.WrapPrivateError(err, "in create token")
 	}
 
 	// TODO: # Add session to redis
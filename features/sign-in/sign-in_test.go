@@ -5,7 +5,6 @@ import (
 	"testing"
 
 	"github.com/Dionid/go-boiler/api/v1/go/proto"
-	"github.com/Dionid/go-boiler/features"
 	fsignin "github.com/Dionid/go-boiler/features/sign-in"
 	inttests "github.com/Dionid/go-boiler/internal/int-tests"
 	"github.com/google/uuid"
@@ -34,11 +33,7 @@ func TestIntSignIn(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		featureDeps := &features.Deps{
-			Logger: testDeps.Logger,
-			MainDb: testDeps.MainDbConnection,
-			Config: testDeps.FeaturesConfig,
-		}
+		featureDeps := testDeps.Deps
 
 		request := &proto.SignInCallRequest{
 			Name: "SignIn",
@@ -3,6 +3,7 @@ package fsignup
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
 	"github.com/Dionid/go-boiler/api/v1/go/proto"
@@ -11,32 +12,36 @@ import (
 	"github.com/Dionid/go-boiler/internal/auth"
 	"github.com/Dionid/go-boiler/pkg/terrors"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// EventSignedUp is published with user id and email after user is created
+const EventSignedUp = "user.signed_up"
+
+// FlagDisabled in FEATURE_FLAGS turns sign up off without restart
+const FlagDisabled = "sign-up-disabled"
+
 func SignUp(ctx context.Context, deps *features.Deps, request *proto.SignUpCallRequest) (*proto.SignUpCallResponse, terrors.Error) {
+	if deps.Runtime().Enabled(FlagDisabled) {
+		return nil, terrors.NewForbiddenError("Sign up is disabled", nil).WithReason("sign_up.disabled", nil)
+	}
+
 	// # Validate request
 	if request.Params.Email == "" {
-		return nil, terrors.NewValidationError("NewValidationError", nil)
+		return nil, terrors.NewValidationError("email is required", nil).WithReason("validation.required", map[string]any{"field": "email"})
 	}
 
 	if request.Params.Password == "" {
-		return nil, terrors.NewValidationError("NewValidationError", nil)
-	}
-
-	// # Query user
-	userExists, _ := maindb.SelectUserByEmail(ctx, deps.MainDb, request.Params.Email)
-	if userExists != nil {
-		return nil, terrors.NewValidationError("Incorrect email or password", nil)
+		return nil, terrors.NewValidationError("password is required", nil).WithReason("validation.required", map[string]any{"field": "password"})
 	}
 
-	// # Hash password
+	// # Hash password before transaction, it's slow
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.Params.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return nil, terrors.NewPrivateError(err.Error())
+		return nil, terrors.WrapPrivateError(err, "in hash password")
 	}
 
-	// # Create User
 	newUser := maindb.NewInsertableUserModel(
 		uuid.New(),
 		request.Params.Email,
@@ -46,13 +51,43 @@ func SignUp(ctx context.Context, deps *features.Deps, request *proto.SignUpCallR
 		"client",
 	)
 
-	if _, err := maindb.InsertIntoUser(ctx, deps.MainDb, newUser); err != nil {
-		return nil, terrors.NewPrivateError("in insert user")
+	err = features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+		// # Query user
+		userExists, err := maindb.SelectUserByEmail(ctx, tx, request.Params.Email)
+		if err != nil && !terrors.IsNotFoundErr(err) {
+			return terrors.WrapPrivateError(err, "in select user")
+		}
+		if userExists != nil {
+			return terrors.NewValidationError("Incorrect email or password", nil).WithReason("auth.invalid_credentials", nil)
+		}
+
+		// # Create User
+		if _, err := maindb.InsertIntoUser(ctx, tx, newUser); err != nil {
+			return terrors.NewDbErr(err)
+		}
+
+		// # Subscribers must not see user of rolled back transaction
+		deps.AfterCommit(ctx, func() {
+			deps.Log(ctx).Info("User signed up", zap.String("userId", newUser.ID.String()))
+			deps.Events.Publish(EventSignedUp, map[string]any{
+				"userId": newUser.ID,
+				"email":  newUser.Email,
+			})
+		})
+
+		return nil
+	})
+	if err != nil {
+		var tErr terrors.Error
+		if errors.As(err, &tErr) {
+			return nil, tErr
+		}
+		return nil, terrors.NewDbErr(err)
 	}
 
-	tokenString, err := auth.CreateToken(deps.Config.JwtSecret, deps.Config.ExpireInSeconds, newUser.ID, newUser.Role)
+	tokenString, err := auth.CreateToken(deps.Config.GetJwtSecret(), deps.Config.ExpireInSeconds, newUser.ID, newUser.Role)
 	if err != nil {
-		return nil, terrors.NewPrivateError("in create token")
+		return nil, terrors.WrapPrivateError(err, "in create token")
 	}
 
 	// TODO: # Add session to redis
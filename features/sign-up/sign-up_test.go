@@ -9,9 +9,26 @@ import (
 	"github.com/Dionid/go-boiler/features"
 	fsignup "github.com/Dionid/go-boiler/features/sign-up"
 	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/Dionid/go-boiler/pkg/terrors"
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestUnitSignUpDisabled(t *testing.T) {
+	deps := &features.Deps{}
+	deps.SetRuntime(&features.Runtime{FeatureFlags: map[string]bool{fsignup.FlagDisabled: true}})
+
+	request := &proto.SignUpCallRequest{
+		Name:   "SignUp",
+		Id:     uuid.New().String(),
+		Params: &proto.SignUpCallRequest_Params{Email: "new@email.com", Password: "1234"},
+	}
+
+	_, err := fsignup.SignUp(context.Background(), deps, request)
+	assert.Equal(t, 403, err.GetCode())
+	assert.Equal(t, "sign_up.disabled", err.(terrors.PublicError).GetReason())
+}
+
 func TestIntSignUp(t *testing.T) {
 	t.Run("SignUp 1", func(t *testing.T) {
 		t.Parallel()
@@ -35,11 +52,7 @@ func TestIntSignUp(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		featureDeps := &features.Deps{
-			Logger: testDeps.Logger,
-			MainDb: testDeps.MainDbConnection,
-			Config: testDeps.FeaturesConfig,
-		}
+		featureDeps := testDeps.Deps
 
 		request := &proto.SignUpCallRequest{
 			Name: "SignIn",
@@ -0,0 +1,167 @@
+package fsubscribeevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/features"
+	fsignup "github.com/Dionid/go-boiler/features/sign-up"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// UserTopicPrefix is prefix of per-user topics, only the user can subscribe to `user:<id>`
+	UserTopicPrefix = "user:"
+	// MaxTopics limits topics of one subscription
+	MaxTopics = 20
+	// SubscriptionBuffer is how many events may wait for subscriber before it's closed as slow
+	SubscriptionBuffer = 64
+	// HeaderSubscribed is sent in header once subscription is authorized
+	HeaderSubscribed = "x-events-subscribed"
+)
+
+// topicRoles lists domain topics and roles allowed to subscribe to them
+var topicRoles = map[string][]string{
+	fsignup.EventSignedUp: {"admin"},
+}
+
+func UserTopic(userId string) string {
+	return UserTopicPrefix + userId
+}
+
+// AuthorizeTopics allows own per-user topic and domain topics by role
+func AuthorizeTopics(claims *auth.Claims, topics []string) terrors.Error {
+	if len(topics) == 0 || len(topics) > MaxTopics {
+		return terrors.NewValidationError(fmt.Sprintf("from 1 to %d topics are required", MaxTopics), nil).WithReason("events.topics_count", map[string]any{"max": MaxTopics})
+	}
+
+	for _, topic := range topics {
+		if strings.HasPrefix(topic, UserTopicPrefix) {
+			if topic == UserTopic(claims.UserId.String()) {
+				continue
+			}
+		} else if roles, ok := topicRoles[topic]; ok {
+			if slices.Contains(roles, claims.Role) {
+				continue
+			}
+		} else {
+			return terrors.NewNotFoundError(fmt.Sprintf("Unknown topic %s", topic), nil).WithReason("events.unknown_topic", map[string]any{"topic": topic})
+		}
+
+		return terrors.NewForbiddenError(fmt.Sprintf("topic %s is not allowed", topic), nil).WithReason("auth.forbidden", nil)
+	}
+
+	return nil
+}
+
+func eventResponse(requestId string, event events.Event) (*proto.SubscribeEventsCallResponse, terrors.Error) {
+	// # Round trip through JSON to get structpb compatible values
+	raw, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in marshal event")
+	}
+
+	data := &structpb.Value{}
+	if err := protojson.Unmarshal(raw, data); err != nil {
+		return nil, terrors.WrapPrivateError(err, "in unmarshal event")
+	}
+
+	return &proto.SubscribeEventsCallResponse{
+		Id: requestId,
+		Result: &proto.SubscribeEventsCallResponse_Result{
+			Result: &proto.SubscribeEventsCallResponse_Result_Success_{
+				Success: &proto.SubscribeEventsCallResponse_Result_Success{
+					EventId: event.Id,
+					Topic:   event.Topic,
+					Data:    data,
+				},
+			},
+		},
+	}, nil
+}
+
+// Stream is server side of SubscribeEvents stream
+type Stream interface {
+	SendHeader(metadata.MD) error
+	Send(*proto.SubscribeEventsCallResponse) error
+}
+
+// SubscribeEvents sends events of requested topics until ctx is done,
+// events after `last_event_id` are replayed first.
+// HeaderSubscribed is sent once subscription is authorized, so bridges can answer before first event.
+func SubscribeEvents(ctx context.Context, deps *features.Deps, request *proto.SubscribeEventsCallRequest, stream Stream) terrors.Error {
+	// # Authorize
+	claims, err := auth.ClaimsFromRequest(deps.Config.GetJwtSecret(), request)
+	if err != nil {
+		if tErr, ok := err.(terrors.Error); ok {
+			return tErr
+		}
+		return terrors.WrapPrivateError(err, "in authorize")
+	}
+
+	if err := AuthorizeTopics(claims, request.GetParams().GetTopics()); err != nil {
+		return err
+	}
+
+	// # Subscribe
+	lastId := deps.Events.LastId()
+	if request.Params.LastEventId != nil {
+		lastId = *request.Params.LastEventId
+	}
+
+	subscription, complete := deps.Events.SubscribeAfter(request.Params.Topics, SubscriptionBuffer, lastId)
+	defer subscription.Close()
+
+	if err := stream.SendHeader(metadata.Pairs(HeaderSubscribed, "true")); err != nil {
+		return terrors.WrapPrivateError(err, "in send header")
+	}
+
+	if !complete {
+		err := stream.Send(&proto.SubscribeEventsCallResponse{
+			Id: request.Id,
+			Result: &proto.SubscribeEventsCallResponse_Result{
+				Result: &proto.SubscribeEventsCallResponse_Result_Success_{
+					Success: &proto.SubscribeEventsCallResponse_Result_Success{Reset_: true},
+				},
+			},
+		})
+		if err != nil {
+			return terrors.WrapPrivateError(err, "in send reset")
+		}
+	}
+
+	// # Stream
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-subscription.Events():
+			if !ok {
+				if subscription.Err() != nil {
+					deps.Log(ctx).Warn("Slow events subscriber is closed", zap.Strings("topics", request.Params.Topics), zap.Error(subscription.Err()))
+					return terrors.NewPublicError(http.StatusTooManyRequests, "Events are consumed too slowly", subscription.Err().Error(), nil).WithReason("events.slow_subscriber", nil)
+				}
+				return nil
+			}
+
+			response, tErr := eventResponse(request.Id, event)
+			if tErr != nil {
+				return tErr
+			}
+			if err := stream.Send(response); err != nil {
+				return terrors.WrapPrivateError(err, "in send event")
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package fsubscribeevents_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/features"
+	fsignup "github.com/Dionid/go-boiler/features/sign-up"
+	fsubscribeevents "github.com/Dionid/go-boiler/features/subscribe-events"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+type testStream struct {
+	header    chan struct{}
+	responses chan *proto.SubscribeEventsCallResponse
+}
+
+func (s *testStream) SendHeader(metadata.MD) error {
+	close(s.header)
+	return nil
+}
+
+func (s *testStream) Send(response *proto.SubscribeEventsCallResponse) error {
+	s.responses <- response
+	return nil
+}
+
+func TestUnitSubscribeEvents(t *testing.T) {
+	deps := &features.Deps{
+		Events: events.NewBus(2),
+		Config: features.Config{
+			JwtSecret:       []byte("secret"),
+			ExpireInSeconds: 10000,
+		},
+	}
+
+	userId := uuid.New()
+	request := func(role string, lastEventId *uint64, topics ...string) *proto.SubscribeEventsCallRequest {
+		token, err := auth.CreateToken(deps.Config.GetJwtSecret(), 10000, userId, role)
+		assert.Nil(t, err)
+
+		return &proto.SubscribeEventsCallRequest{
+			Name:   "SubscribeEvents",
+			Id:     uuid.New().String(),
+			Meta:   &proto.Meta{Token: &token},
+			Params: &proto.SubscribeEventsCallRequest_Params{Topics: topics, LastEventId: lastEventId},
+		}
+	}
+
+	subscribe := func(request *proto.SubscribeEventsCallRequest) (*testStream, chan terrors.Error, context.CancelFunc) {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := &testStream{header: make(chan struct{}), responses: make(chan *proto.SubscribeEventsCallResponse, 10)}
+		result := make(chan terrors.Error, 1)
+		go func() { result <- fsubscribeevents.SubscribeEvents(ctx, deps, request, stream) }()
+
+		select {
+		case <-stream.header:
+		case err := <-result:
+			result <- err
+		case <-time.After(time.Second):
+			t.Fatal("subscription is not started")
+		}
+
+		return stream, result, cancel
+	}
+
+	receive := func(stream *testStream) *proto.SubscribeEventsCallResponse_Result_Success {
+		select {
+		case response := <-stream.responses:
+			return response.GetResult().GetSuccess()
+		case <-time.After(time.Second):
+			t.Fatal("event is not received")
+			return nil
+		}
+	}
+
+	t.Run("authorization", func(t *testing.T) {
+		_, result, cancel := subscribe(request("client", nil, fsignup.EventSignedUp))
+		defer cancel()
+		assert.Equal(t, http.StatusForbidden, (<-result).GetCode())
+
+		_, result, cancel = subscribe(request("client", nil, fsubscribeevents.UserTopic(uuid.NewString())))
+		defer cancel()
+		assert.Equal(t, http.StatusForbidden, (<-result).GetCode())
+
+		_, result, cancel = subscribe(request("admin", nil, "unknown"))
+		defer cancel()
+		assert.Equal(t, http.StatusNotFound, (<-result).GetCode())
+
+		_, result, cancel = subscribe(request("admin", nil))
+		defer cancel()
+		assert.Equal(t, http.StatusBadRequest, (<-result).GetCode())
+	})
+
+	t.Run("streams new events of topics", func(t *testing.T) {
+		stream, result, cancel := subscribe(request("client", nil, fsubscribeevents.UserTopic(userId.String())))
+
+		deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "a@b.c"})
+		deps.Events.Publish(fsubscribeevents.UserTopic(userId.String()), map[string]any{"status": "paid"})
+
+		event := receive(stream)
+		assert.Equal(t, fsubscribeevents.UserTopic(userId.String()), event.Topic)
+		assert.Equal(t, "paid", event.Data.GetStructValue().Fields["status"].GetStringValue())
+
+		cancel()
+		assert.Nil(t, <-result)
+	})
+
+	t.Run("resumes after last event id", func(t *testing.T) {
+		lastEventId := deps.Events.LastId()
+		deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "a@b.c"})
+
+		stream, _, cancel := subscribe(request("admin", &lastEventId, fsignup.EventSignedUp))
+		defer cancel()
+		event := receive(stream)
+		assert.Equal(t, lastEventId+1, event.EventId)
+		assert.False(t, event.Reset_)
+	})
+
+	t.Run("resets when events were evicted", func(t *testing.T) {
+		lastEventId := deps.Events.LastId()
+		for range 3 {
+			deps.Events.Publish(fsignup.EventSignedUp, map[string]any{"email": "a@b.c"})
+		}
+
+		stream, _, cancel := subscribe(request("admin", &lastEventId, fsignup.EventSignedUp))
+		defer cancel()
+		assert.True(t, receive(stream).Reset_)
+		assert.Equal(t, lastEventId+2, receive(stream).EventId)
+		assert.Equal(t, lastEventId+3, receive(stream).EventId)
+	})
+}
@@ -3,10 +3,8 @@ package features_test
 import (
 	"context"
 	"fmt"
-	"sync"
 	"testing"
 
-	"github.com/Dionid/go-boiler/features"
 	inttests "github.com/Dionid/go-boiler/internal/int-tests"
 )
 
@@ -32,19 +30,8 @@ func TestIntTemplate(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// # Global WaitGroup
-	gwg := &sync.WaitGroup{}
-
-	// # Graceful shutdown emitter
-	gse := make(chan string, 1)
-
-	featureDeps := &features.Deps{
-		testDeps.Logger,
-		gwg,
-		gse,
-		testDeps.MainDbConnection,
-		testDeps.FeaturesConfig,
-	}
+	// # Features deps assembled by test app
+	featureDeps := testDeps.Deps
 
 	// # WRITE YOUR TEST HERE
 	// ...
@@ -0,0 +1,177 @@
+package features
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Dionid/go-boiler/dbs/maindb"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	defaultTxMaxRetries = 3
+	// txBackoffMax caps jittered backoff between retries, so big MaxRetries don't overflow it
+	txBackoffMax = 500 * time.Millisecond
+)
+
+type TxOptions struct {
+	// Isolation of outermost transaction, nested calls join it with any isolation
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is how many times fn is rerun after serialization failure or deadlock
+	MaxRetries int
+}
+
+type TxOption func(o *TxOptions)
+
+func TxIsolation(level sql.IsolationLevel) TxOption {
+	return func(o *TxOptions) {
+		o.Isolation = level
+	}
+}
+
+func TxReadOnly() TxOption {
+	return func(o *TxOptions) {
+		o.ReadOnly = true
+	}
+}
+
+func TxMaxRetries(n int) TxOption {
+	return func(o *TxOptions) {
+		o.MaxRetries = n
+	}
+}
+
+// txState is transaction shared by WithTx calls of one context
+type txState struct {
+	db          *sqlx.DB
+	tx          *sqlx.Tx
+	savepoints  int
+	afterCommit []func()
+}
+
+type txCtxKey struct{}
+
+func txFromContext(ctx context.Context, db *sqlx.DB) *txState {
+	state, ok := ctx.Value(txCtxKey{}).(*txState)
+	if !ok || state.db != db {
+		return nil
+	}
+
+	return state
+}
+
+// DB returns transaction of ctx started by WithTx or MainDb outside of it,
+// so features called inside WithTx join the outer transaction
+func (deps *Deps) DB(ctx context.Context) maindb.DB {
+	if state := txFromContext(ctx, deps.MainDb); state != nil {
+		return state.tx
+	}
+
+	return deps.MainDb
+}
+
+// WithTx runs fn in transaction and commits it when fn returns nil.
+// Transaction is passed to nested calls with ctx: nested WithTx runs in savepoint,
+// which is rolled back on its error without aborting the outer transaction.
+// Outermost call reruns fn on serialization failure or deadlock, so fn must not have
+// side effects outside of tx, use AfterCommit for them.
+// Transaction isn't safe for concurrent use, don't pass ctx to other goroutines.
+func WithTx(ctx context.Context, deps *Deps, fn func(ctx context.Context, tx maindb.DB) error, opts ...TxOption) error {
+	if state := txFromContext(ctx, deps.MainDb); state != nil {
+		return withSavepoint(ctx, state, fn)
+	}
+
+	options := TxOptions{MaxRetries: defaultTxMaxRetries}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := runTx(ctx, deps.MainDb, options, fn)
+		if err == nil || !terrors.IsRetryable(err) || attempt >= options.MaxRetries {
+			return err
+		}
+
+		// # Jittered backoff, so conflicting transactions don't collide again
+		backoff := time.Duration(rand.Int64N(int64(min(10*time.Millisecond<<min(attempt, 6), txBackoffMax))))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+func runTx(ctx context.Context, db *sqlx.DB, options TxOptions, fn func(ctx context.Context, tx maindb.DB) error) error {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{Isolation: options.Isolation, ReadOnly: options.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	state := &txState{db: db, tx: tx}
+	if err := fn(context.WithValue(ctx, txCtxKey{}, state), tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	for _, hook := range state.afterCommit {
+		hook()
+	}
+
+	return nil
+}
+
+func withSavepoint(ctx context.Context, state *txState, fn func(ctx context.Context, tx maindb.DB) error) error {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+	hooks := len(state.afterCommit)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return err
+	}
+
+	if err := fn(ctx, state.tx); err != nil {
+		// # Hooks of rolled back work must not run
+		state.afterCommit = state.afterCommit[:hooks]
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return fmt.Errorf("%w, rollback to savepoint: %v", err, rollbackErr)
+		}
+		return err
+	}
+
+	_, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+// JoinTx passes tx started outside of features (e.g. by interceptor) to WithTx calls of returned ctx,
+// they run in its savepoints. Caller commits tx and then calls afterCommit to run AfterCommit hooks.
+func JoinTx(ctx context.Context, db *sqlx.DB, tx *sqlx.Tx) (txCtx context.Context, afterCommit func()) {
+	state := &txState{db: db, tx: tx}
+
+	return context.WithValue(ctx, txCtxKey{}, state), func() {
+		for _, hook := range state.afterCommit {
+			hook()
+		}
+	}
+}
+
+// AfterCommit runs hook after outermost transaction of ctx is committed,
+// it isn't run on rollback. Outside of WithTx hook runs immediately
+func (deps *Deps) AfterCommit(ctx context.Context, hook func()) {
+	state := txFromContext(ctx, deps.MainDb)
+	if state == nil {
+		hook()
+		return
+	}
+
+	state.afterCommit = append(state.afterCommit, hook)
+}
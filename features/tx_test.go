@@ -0,0 +1,136 @@
+package features_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/dbs/maindb"
+	"github.com/Dionid/go-boiler/features"
+	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitTxOutside(t *testing.T) {
+	deps := &features.Deps{}
+
+	ran := false
+	deps.AfterCommit(context.Background(), func() { ran = true })
+	assert.True(t, ran)
+}
+
+func insertUser(ctx context.Context, tx maindb.DB, email string) error {
+	_, err := maindb.InsertIntoUser(ctx, tx, maindb.NewInsertableUserModel(uuid.New(), email, "hash", time.Time{}, sql.NullTime{}, "client"))
+	return err
+}
+
+func userExists(t *testing.T, ctx context.Context, db maindb.DB, email string) bool {
+	_, err := maindb.SelectUserByEmail(ctx, db, email)
+	if terrors.IsNotFoundErr(err) {
+		return false
+	}
+	assert.Nil(t, err)
+	return true
+}
+
+func TestIntWithTx(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testDeps, err := inttests.InitTestDeps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		err := testDeps.Cleanup()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	deps := testDeps.Deps
+	failure := errors.New("failure")
+
+	t.Run("commit runs hooks, rollback doesn't", func(t *testing.T) {
+		hooks := 0
+		err := features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+			deps.AfterCommit(ctx, func() { hooks++ })
+			assert.Equal(t, 0, hooks)
+			return insertUser(ctx, tx, "committed@email.com")
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, hooks)
+		assert.True(t, userExists(t, ctx, deps.MainDb, "committed@email.com"))
+
+		err = features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+			deps.AfterCommit(ctx, func() { hooks++ })
+			if err := insertUser(ctx, tx, "rolled-back@email.com"); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, hooks)
+		assert.False(t, userExists(t, ctx, deps.MainDb, "rolled-back@email.com"))
+	})
+
+	t.Run("nested call joins outer transaction in savepoint", func(t *testing.T) {
+		hooks := 0
+		err := features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+			assert.Equal(t, tx, deps.DB(ctx))
+			if err := insertUser(ctx, deps.DB(ctx), "outer@email.com"); err != nil {
+				return err
+			}
+
+			err := features.WithTx(ctx, deps, func(ctx context.Context, nested maindb.DB) error {
+				assert.Equal(t, tx, nested)
+				deps.AfterCommit(ctx, func() { hooks++ })
+				if err := insertUser(ctx, nested, "nested@email.com"); err != nil {
+					return err
+				}
+				return failure
+			})
+			assert.ErrorIs(t, err, failure)
+
+			// # Outer work is visible in tx only
+			assert.True(t, userExists(t, ctx, tx, "outer@email.com"))
+			assert.False(t, userExists(t, ctx, deps.MainDb, "outer@email.com"))
+
+			return features.WithTx(ctx, deps, func(ctx context.Context, nested maindb.DB) error {
+				return insertUser(ctx, nested, "released@email.com")
+			})
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 0, hooks)
+		assert.True(t, userExists(t, ctx, deps.MainDb, "outer@email.com"))
+		assert.True(t, userExists(t, ctx, deps.MainDb, "released@email.com"))
+		assert.False(t, userExists(t, ctx, deps.MainDb, "nested@email.com"))
+	})
+
+	t.Run("serialization failure is retried", func(t *testing.T) {
+		attempts := 0
+		err := features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+			attempts++
+			if attempts < 3 {
+				return &pq.Error{Code: "40001"}
+			}
+			return nil
+		}, features.TxIsolation(sql.LevelSerializable))
+		assert.Nil(t, err)
+		assert.Equal(t, 3, attempts)
+
+		attempts = 0
+		err = features.WithTx(ctx, deps, func(ctx context.Context, tx maindb.DB) error {
+			attempts++
+			return &pq.Error{Code: "40P01"}
+		}, features.TxMaxRetries(1))
+		assert.NotNil(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+}
@@ -1,7 +1,11 @@
 package auth
 
 import (
+	"context"
+	"slices"
+
 	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	"github.com/Dionid/go-boiler/pkg/certs"
 	"github.com/Dionid/go-boiler/pkg/terrors"
 )
 
@@ -9,20 +13,30 @@ type Request interface {
 	GetMeta() *proto.Meta
 }
 
-func AuthorizeByRoles(jwtSecret []byte, roles []string, request Request) error {
+// ClaimsFromRequest parses token from request meta
+func ClaimsFromRequest(jwtSecret []byte, request Request) (*Claims, error) {
 	meta := request.GetMeta()
 
 	if meta == nil {
-		return terrors.NewUnauthorizedError("meta is required", nil)
+		return nil, terrors.NewUnauthorizedError("meta is required", nil).WithReason("auth.meta_required", nil)
 	}
 
 	if meta.Token == nil {
-		return terrors.NewUnauthorizedError("token is required", nil)
+		return nil, terrors.NewUnauthorizedError("token is required", nil).WithReason("auth.token_required", nil)
 	}
 
 	claims, err := ParseToken(jwtSecret, *meta.Token)
 	if err != nil {
-		return terrors.NewUnauthorizedError("invalid token", nil)
+		return nil, terrors.NewUnauthorizedError("invalid token", nil).WithReason("auth.invalid_token", nil)
+	}
+
+	return claims, nil
+}
+
+func AuthorizeByRoles(jwtSecret []byte, roles []string, request Request) error {
+	claims, err := ClaimsFromRequest(jwtSecret, request)
+	if err != nil {
+		return err
 	}
 
 	for _, role := range roles {
@@ -31,5 +45,22 @@ func AuthorizeByRoles(jwtSecret []byte, roles []string, request Request) error {
 		}
 	}
 
-	return terrors.NewForbiddenError("invalid token", nil)
+	return terrors.NewForbiddenError("invalid token", nil).WithReason("auth.forbidden", nil)
+}
+
+// AuthorizeByClientCertificate allows mTLS clients whose verified certificate
+// has one of names as subject CN, DNS or URI SAN
+func AuthorizeByClientCertificate(ctx context.Context, names []string) error {
+	identity, ok := certs.IdentityFromContext(ctx)
+	if !ok {
+		return terrors.NewUnauthorizedError("client certificate is required", nil).WithReason("auth.client_certificate_required", nil)
+	}
+
+	for _, name := range names {
+		if identity.Subject == name || slices.Contains(identity.DNSNames, name) || slices.Contains(identity.URIs, name) {
+			return nil
+		}
+	}
+
+	return terrors.NewForbiddenError("client certificate is not allowed", nil).WithReason("auth.forbidden", nil)
 }
@@ -0,0 +1,359 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/Dionid/go-boiler/dbs/maindb"
+	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/internal/auth"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/Dionid/sqli"
+	"github.com/jmoiron/sqlx"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	Header      = "Idempotency-Key"
+	MetadataKey = "idempotency-key"
+
+	maxKeyLength = 255
+)
+
+// Key returns `Meta.idempotencyKey` or `idempotency-key` metadata, empty if there is none
+func Key(ctx context.Context, req any) string {
+	if request, ok := req.(auth.Request); ok && request.GetMeta().GetIdempotencyKey() != "" {
+		return request.GetMeta().GetIdempotencyKey()
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+
+	return ""
+}
+
+// RequestHash identifies request body: meta and call id differ between retries, so they are ignored
+func RequestHash(req proto.Message) (string, error) {
+	clone := proto.Clone(req)
+	message := clone.ProtoReflect()
+	for _, name := range []protoreflect.Name{"meta", "id"} {
+		if field := message.Descriptor().Fields().ByName(name); field != nil {
+			message.Clear(field)
+		}
+	}
+
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// UnaryServerInterceptor stores first response (or public error) of methods called with idempotency key
+// per key, user and method for ttl and replays it on retries.
+// Same key with different request is rejected, concurrent duplicates wait for the first call on row lock.
+// Handler runs in the transaction holding the lock (features.WithTx joins it), so stored response
+// is committed atomically with its side effects and keyed call holds one connection.
+// Calls without key and methods not listed pass through, as well as all calls when db is nil.
+func UnaryServerInterceptor(db *sqlx.DB, jwtSecret func() []byte, ttl time.Duration, methods ...string) grpc.UnaryServerInterceptor {
+	idempotent := map[string]struct{}{}
+	for _, method := range methods {
+		idempotent[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if _, ok := idempotent[info.FullMethod]; !ok || db == nil {
+			return handler(ctx, req)
+		}
+
+		key := Key(ctx, req)
+		if key == "" {
+			return handler(ctx, req)
+		}
+		if len(key) > maxKeyLength {
+			return nil, terrors.NewValidationError("Idempotency key is too long", nil).WithReason("idempotency.key_too_long", map[string]any{"max": maxKeyLength})
+		}
+
+		message, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		userId := ""
+		if request, ok := req.(auth.Request); ok {
+			if claims, err := auth.ClaimsFromRequest(jwtSecret(), request); err == nil {
+				userId = claims.UserId.String()
+			}
+		}
+
+		return call(ctx, db, ttl, maindb.InsertableIdempotencyKeyModel{
+			Key:       key,
+			UserID:    userId,
+			Method:    info.FullMethod,
+			CreatedAt: time.Now(),
+			ExpiresAt: time.Now().Add(ttl),
+		}, message, handler)
+	}
+}
+
+func call(ctx context.Context, db *sqlx.DB, ttl time.Duration, row maindb.InsertableIdempotencyKeyModel, req proto.Message, handler grpc.UnaryHandler) (any, error) {
+	requestHash, err := RequestHash(req)
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in idempotency request hash")
+	}
+	row.RequestHash = requestHash
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, terrors.NewDbErr(err)
+	}
+	defer tx.Rollback()
+
+	stored, err := lock(ctx, tx, row)
+	if err != nil {
+		return nil, terrors.NewDbErr(err)
+	}
+
+	// # Completed call is replayed until it expires
+	if stored.CompletedAt.Valid && stored.ExpiresAt.After(time.Now()) {
+		if stored.RequestHash != requestHash {
+			return nil, terrors.NewConflictError("Idempotency key is already used with another request", nil).WithReason("idempotency.key_reused", nil)
+		}
+
+		return replay(stored, row.Method, req)
+	}
+
+	txCtx, afterCommit := features.JoinTx(ctx, db, tx)
+	resp, handlerErr := handler(txCtx, req)
+
+	// # Expired row is reused by the new call
+	values := []sqli.Statement{
+		sqli.SET_VALUE(maindb.IdempotencyKey.RequestHash, requestHash),
+		sqli.SET_VALUE(maindb.IdempotencyKey.CreatedAt, row.CreatedAt),
+		sqli.SET_VALUE(maindb.IdempotencyKey.CompletedAt, sql.NullTime{Time: time.Now(), Valid: true}),
+		sqli.SET_VALUE(maindb.IdempotencyKey.ExpiresAt, time.Now().Add(ttl)),
+	}
+
+	if handlerErr != nil {
+		tErr, ok := handlerErr.(terrors.Error)
+		// # Private errors (e.g. DB is down) are not final, so retry runs the call again
+		if _, private := handlerErr.(*terrors.PrivateError); !ok || private {
+			return resp, handlerErr
+		}
+
+		encoded, err := proto.Marshal(terrors.ToGRPCStatus(tErr).Proto())
+		if err != nil {
+			return resp, handlerErr
+		}
+
+		values = append(values,
+			sqli.SET_VALUE(maindb.IdempotencyKey.Response, []byte(nil)),
+			sqli.SET_VALUE(maindb.IdempotencyKey.Error, encoded),
+		)
+	} else {
+		message, ok := resp.(proto.Message)
+		if !ok {
+			return nil, terrors.NewPrivateError("idempotency response of " + row.Method + " isn't proto message")
+		}
+
+		encoded, err := proto.Marshal(message)
+		if err != nil {
+			return nil, terrors.WrapPrivateError(err, "in idempotency response marshal")
+		}
+
+		values = append(values,
+			sqli.SET_VALUE(maindb.IdempotencyKey.Response, encoded),
+			sqli.SET_VALUE(maindb.IdempotencyKey.Error, []byte(nil)),
+		)
+	}
+
+	query, err := sqli.Query(
+		sqli.UPDATE(maindb.IdempotencyKey),
+		sqli.SET(values...),
+		sqli.WHERE(
+			sqli.AND(
+				sqli.EQUAL(maindb.IdempotencyKey.Key, row.Key),
+				sqli.EQUAL(maindb.IdempotencyKey.UserID, row.UserID),
+				sqli.EQUAL(maindb.IdempotencyKey.Method, row.Method),
+			),
+		),
+	)
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in idempotency update query")
+	}
+
+	if _, err := tx.ExecContext(ctx, query.SQL, query.Args...); err != nil {
+		return nil, terrors.NewDbErr(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, terrors.NewDbErr(err)
+	}
+	afterCommit()
+
+	return resp, handlerErr
+}
+
+// lock inserts pending row if there is none and locks it till the end of tx
+func lock(ctx context.Context, tx *sqlx.Tx, row maindb.InsertableIdempotencyKeyModel) (*maindb.IdempotencyKeyModel, error) {
+	insert, err := sqli.Query(
+		sqli.INSERT_INTO(
+			maindb.IdempotencyKey,
+			maindb.IdempotencyKey.Key,
+			maindb.IdempotencyKey.UserID,
+			maindb.IdempotencyKey.Method,
+			maindb.IdempotencyKey.RequestHash,
+			maindb.IdempotencyKey.CreatedAt,
+			maindb.IdempotencyKey.ExpiresAt,
+		),
+		sqli.VALUES(
+			sqli.ValueSet(
+				sqli.VALUE(maindb.IdempotencyKey.Key, row.Key),
+				sqli.VALUE(maindb.IdempotencyKey.UserID, row.UserID),
+				sqli.VALUE(maindb.IdempotencyKey.Method, row.Method),
+				sqli.VALUE(maindb.IdempotencyKey.RequestHash, row.RequestHash),
+				sqli.VALUE(maindb.IdempotencyKey.CreatedAt, row.CreatedAt),
+				sqli.VALUE(maindb.IdempotencyKey.ExpiresAt, row.ExpiresAt),
+			),
+		),
+		sqli.NewStatement("ON CONFLICT DO NOTHING"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, insert.SQL, insert.Args...); err != nil {
+		return nil, err
+	}
+
+	// # Concurrent duplicate waits here until the first call commits
+	selectQuery, err := sqli.Query(
+		sqli.SELECT(
+			maindb.IdempotencyKey.AllColumns(),
+		),
+		sqli.FROM(maindb.IdempotencyKey),
+		sqli.WHERE(
+			sqli.AND(
+				sqli.EQUAL(maindb.IdempotencyKey.Key, row.Key),
+				sqli.EQUAL(maindb.IdempotencyKey.UserID, row.UserID),
+				sqli.EQUAL(maindb.IdempotencyKey.Method, row.Method),
+			),
+		),
+		sqli.NewStatement("FOR UPDATE"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &maindb.IdempotencyKeyModel{}
+	err = tx.QueryRowxContext(ctx, selectQuery.SQL, selectQuery.Args...).Scan(
+		&stored.Key,
+		&stored.UserID,
+		&stored.Method,
+		&stored.RequestHash,
+		&stored.Response,
+		&stored.Error,
+		&stored.CreatedAt,
+		&stored.CompletedAt,
+		&stored.ExpiresAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return stored, nil
+}
+
+// replay decodes stored response into output type of the method or stored error,
+// response gets id of the retry, so bridges match it to their call
+func replay(stored *maindb.IdempotencyKeyModel, fullMethod string, req proto.Message) (any, error) {
+	if stored.Error != nil {
+		st := &spb.Status{}
+		if err := proto.Unmarshal(stored.Error, st); err != nil {
+			return nil, terrors.WrapPrivateError(err, "in idempotency error unmarshal")
+		}
+
+		return nil, terrors.FromGRPCStatus(status.FromProto(st))
+	}
+
+	// # "/package.Service/Method" -> "package.Service.Method"
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1))
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in idempotency method lookup")
+	}
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, terrors.NewPrivateError("idempotency method " + fullMethod + " is not a method")
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, terrors.WrapPrivateError(err, "in idempotency response type lookup")
+	}
+
+	resp := messageType.New().Interface()
+	if err := proto.Unmarshal(stored.Response, resp); err != nil {
+		return nil, terrors.WrapPrivateError(err, "in idempotency response unmarshal")
+	}
+
+	requestId := req.ProtoReflect().Descriptor().Fields().ByName("id")
+	responseId := resp.ProtoReflect().Descriptor().Fields().ByName("id")
+	if requestId != nil && responseId != nil && requestId.Kind() == responseId.Kind() {
+		resp.ProtoReflect().Set(responseId, req.ProtoReflect().Get(requestId))
+	}
+
+	return resp, nil
+}
+
+// DeleteExpired removes keys that can't be replayed anymore
+func DeleteExpired(ctx context.Context, db maindb.DB) (int64, error) {
+	query, err := sqli.Query(
+		sqli.DELETE_FROM(maindb.IdempotencyKey),
+		sqli.WHERE(
+			sqli.LESS(maindb.IdempotencyKey.ExpiresAt, time.Now()),
+		),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := db.ExecContext(ctx, query.SQL, query.Args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Cleanup deletes expired keys every interval until ctx is done
+func Cleanup(ctx context.Context, db maindb.DB, interval time.Duration, onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := DeleteExpired(ctx, db); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
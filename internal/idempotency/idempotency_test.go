@@ -0,0 +1,223 @@
+package idempotency_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/api/v1/go/proto"
+	fsignup "github.com/Dionid/go-boiler/features/sign-up"
+	"github.com/Dionid/go-boiler/internal/idempotency"
+	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func signUpRequest(email string, key string) *proto.SignUpCallRequest {
+	return &proto.SignUpCallRequest{
+		Name:   "SignUp",
+		Id:     uuid.NewString(),
+		Meta:   &proto.Meta{IdempotencyKey: key, TraceId: uuid.NewString()},
+		Params: &proto.SignUpCallRequest_Params{Email: email, Password: "123456"},
+	}
+}
+
+func TestUnitIdempotency(t *testing.T) {
+	t.Run("key from meta or metadata", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotency.MetadataKey, "header"))
+
+		assert.Equal(t, "meta", idempotency.Key(ctx, signUpRequest("a@b.c", "meta")))
+		assert.Equal(t, "header", idempotency.Key(ctx, signUpRequest("a@b.c", "")))
+		assert.Equal(t, "", idempotency.Key(context.Background(), signUpRequest("a@b.c", "")))
+	})
+
+	t.Run("request hash ignores meta and id", func(t *testing.T) {
+		first, err := idempotency.RequestHash(signUpRequest("a@b.c", "1"))
+		assert.Nil(t, err)
+		retry, err := idempotency.RequestHash(signUpRequest("a@b.c", "2"))
+		assert.Nil(t, err)
+		other, err := idempotency.RequestHash(signUpRequest("b@b.c", "1"))
+		assert.Nil(t, err)
+
+		assert.Equal(t, first, retry)
+		assert.NotEqual(t, first, other)
+	})
+
+	t.Run("passes through without db", func(t *testing.T) {
+		interceptor := idempotency.UnaryServerInterceptor(nil, func() []byte { return nil }, time.Hour, proto.MainApi_SignUp_FullMethodName)
+		calls := 0
+		for range 2 {
+			_, err := interceptor(context.Background(), signUpRequest("a@b.c", "1"), &grpc.UnaryServerInfo{FullMethod: proto.MainApi_SignUp_FullMethodName}, func(ctx context.Context, req any) (any, error) {
+				calls++
+				return &proto.SignUpCallResponse{}, nil
+			})
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 2, calls)
+	})
+}
+
+func TestIntIdempotency(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testDeps, err := inttests.InitTestDeps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		err := testDeps.Cleanup()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	db := testDeps.Deps.MainDb
+	interceptor := idempotency.UnaryServerInterceptor(db, testDeps.Deps.Config.GetJwtSecret, time.Hour, proto.MainApi_SignUp_FullMethodName)
+	info := &grpc.UnaryServerInfo{FullMethod: proto.MainApi_SignUp_FullMethodName}
+
+	calls := atomic.Int32{}
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+
+		if req.(*proto.SignUpCallRequest).Params.Email == "taken@b.c" {
+			return nil, terrors.NewConflictError("User already exists", nil)
+		}
+
+		return &proto.SignUpCallResponse{
+			Id: req.(*proto.SignUpCallRequest).Id,
+			Result: &proto.SignUpCallResponse_Result{
+				Result: &proto.SignUpCallResponse_Result_Success_{
+					Success: &proto.SignUpCallResponse_Result_Success{Token: uuid.NewString()},
+				},
+			},
+		}, nil
+	}
+
+	t.Run("replays first response", func(t *testing.T) {
+		calls.Store(0)
+		key := uuid.NewString()
+
+		first, err := interceptor(ctx, signUpRequest("a@b.c", key), info, handler)
+		assert.Nil(t, err)
+		retryRequest := signUpRequest("a@b.c", key)
+		retry, err := interceptor(ctx, retryRequest, info, handler)
+		assert.Nil(t, err)
+
+		assert.Equal(t, int32(1), calls.Load())
+		assert.Equal(t, retryRequest.Id, retry.(*proto.SignUpCallResponse).Id)
+		assert.Equal(t,
+			first.(*proto.SignUpCallResponse).GetResult().GetSuccess().GetToken(),
+			retry.(*proto.SignUpCallResponse).GetResult().GetSuccess().GetToken(),
+		)
+	})
+
+	t.Run("replays public error", func(t *testing.T) {
+		calls.Store(0)
+		key := uuid.NewString()
+
+		_, err := interceptor(ctx, signUpRequest("taken@b.c", key), info, handler)
+		assert.Equal(t, http.StatusConflict, err.(terrors.Error).GetCode())
+		_, err = interceptor(ctx, signUpRequest("taken@b.c", key), info, handler)
+		assert.Equal(t, http.StatusConflict, err.(terrors.Error).GetCode())
+
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("rejects key reused with another request", func(t *testing.T) {
+		key := uuid.NewString()
+
+		_, err := interceptor(ctx, signUpRequest("a@b.c", key), info, handler)
+		assert.Nil(t, err)
+		_, err = interceptor(ctx, signUpRequest("b@b.c", key), info, handler)
+		assert.Equal(t, "idempotency.key_reused", err.(terrors.PublicError).GetReason())
+	})
+
+	t.Run("concurrent duplicates run once", func(t *testing.T) {
+		calls.Store(0)
+		key := uuid.NewString()
+
+		wg := sync.WaitGroup{}
+		tokens := make([]string, 5)
+		for i := range tokens {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				resp, err := interceptor(ctx, signUpRequest("a@b.c", key), info, handler)
+				assert.Nil(t, err)
+				tokens[i] = resp.(*proto.SignUpCallResponse).GetResult().GetSuccess().GetToken()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls.Load())
+		for _, token := range tokens {
+			assert.Equal(t, tokens[0], token)
+		}
+	})
+
+	t.Run("more concurrent calls than connections", func(t *testing.T) {
+		db.SetMaxOpenConns(2)
+		t.Cleanup(func() { db.SetMaxOpenConns(0) })
+
+		// # SignUp joins transaction of interceptor, so keyed call holds one connection
+		signUp := func(ctx context.Context, req any) (any, error) {
+			resp, err := fsignup.SignUp(ctx, testDeps.Deps, req.(*proto.SignUpCallRequest))
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		keys := []string{uuid.NewString(), uuid.NewString(), uuid.NewString()}
+		tokens := make([]string, 9)
+		wg := sync.WaitGroup{}
+		for i := range tokens {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				key := keys[i%len(keys)]
+				resp, err := interceptor(timeoutCtx, signUpRequest(key+"@b.c", key), info, signUp)
+				assert.Nil(t, err)
+				if err == nil {
+					tokens[i] = resp.(*proto.SignUpCallResponse).GetResult().GetSuccess().GetToken()
+				}
+			}()
+		}
+		wg.Wait()
+
+		for i, token := range tokens {
+			assert.NotEmpty(t, token)
+			assert.Equal(t, tokens[i%len(keys)], token)
+		}
+	})
+
+	t.Run("expired keys are deleted and run again", func(t *testing.T) {
+		calls.Store(0)
+		key := uuid.NewString()
+		expiring := idempotency.UnaryServerInterceptor(db, testDeps.Deps.Config.GetJwtSecret, time.Millisecond, proto.MainApi_SignUp_FullMethodName)
+
+		_, err := expiring(ctx, signUpRequest("a@b.c", key), info, handler)
+		assert.Nil(t, err)
+		time.Sleep(10 * time.Millisecond)
+
+		deleted, err := idempotency.DeleteExpired(ctx, db)
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+
+		_, err = expiring(ctx, signUpRequest("b@b.c", key), info, handler)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+}
@@ -5,12 +5,16 @@ import (
 	"fmt"
 	"math/rand"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 
 	"github.com/Dionid/go-boiler/features"
+	"github.com/Dionid/go-boiler/pkg/app"
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/Dionid/go-boiler/pkg/lifecycle"
 	"go.uber.org/zap"
 )
 
@@ -19,7 +23,11 @@ type TestDeps struct {
 	Logger           *zap.Logger
 	MainDbConnection *sqlx.DB
 	FeaturesConfig   features.Config
-	Cleanup          func() error
+	// App holds test components, Cleanup stops it
+	App *app.App
+	// Deps are ready to use features dependencies
+	Deps    *features.Deps
+	Cleanup func() error
 }
 
 var letterRunes = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -90,14 +98,43 @@ func InitTestDeps(ctx context.Context) (*TestDeps, error) {
 		return nil, err
 	}
 
+	a := app.New(logger)
+
+	// # Main DB from template
 	tempDbName := "go-boiler-" + RandStringRunes(10)
 
-	if err = createTemplateDb(ctx, config.MainDbConnection, "go-boiler", tempDbName, 0); err != nil {
+	mainDbConnectionTemplate, err := sqlx.Open("postgres", strings.Replace(config.MainDbConnection, "go-boiler", tempDbName, 1))
+	if err != nil {
 		return nil, err
 	}
 
-	mainDbConnectionTemplate, err := sqlx.Open("postgres", strings.Replace(config.MainDbConnection, "go-boiler", tempDbName, 1))
-	if err != nil {
+	a.Register(app.Component{
+		Name: "main db",
+		Start: func(ctx context.Context) error {
+			return createTemplateDb(ctx, config.MainDbConnection, "go-boiler", tempDbName, 0)
+		},
+		Stop: func(ctx context.Context) error {
+			mainDbConnectionTemplate.Close()
+			return dropTemplateTable(ctx, config.MainDbConnection, tempDbName)
+		},
+	})
+
+	// # Background workers
+	globalCtx, cancel := context.WithCancel(ctx)
+	gwg := &sync.WaitGroup{}
+
+	a.Register(app.Component{
+		Name:      "workers",
+		DependsOn: []string{"main db"},
+		Stop: func(ctx context.Context) error {
+			cancel()
+			return lifecycle.Wait(ctx, gwg)
+		},
+	})
+
+	if err := a.Start(ctx); err != nil {
+		cancel()
+		mainDbConnectionTemplate.Close()
 		return nil, err
 	}
 
@@ -107,16 +144,22 @@ func InitTestDeps(ctx context.Context) (*TestDeps, error) {
 	}
 
 	result := &TestDeps{
-		config,
-		logger,
-		mainDbConnectionTemplate,
-		featuresConfig,
-		func() error {
-			mainDbConnectionTemplate.Close()
-			if err = dropTemplateTable(ctx, config.MainDbConnection, tempDbName); err != nil {
-				return err
-			}
-			return nil
+		Config:           config,
+		Logger:           logger,
+		MainDbConnection: mainDbConnectionTemplate,
+		FeaturesConfig:   featuresConfig,
+		App:              a,
+		Deps: &features.Deps{
+			Logger:                  logger,
+			GlobalCtx:               globalCtx,
+			GlobalWg:                gwg,
+			GracefulShutdownEmitter: make(chan string, 1),
+			MainDb:                  mainDbConnectionTemplate,
+			Events:                  events.NewBus(100),
+			Config:                  featuresConfig,
+		},
+		Cleanup: func() error {
+			return a.Stop(ctx)
 		},
 	}
 
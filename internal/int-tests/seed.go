@@ -49,7 +49,7 @@ func Seed(
 		return nil, err
 	}
 
-	jwtToken, err := auth.CreateToken(featureConfig.JwtSecret, featureConfig.ExpireInSeconds, user.ID, user.Role)
+	jwtToken, err := auth.CreateToken(featureConfig.GetJwtSecret(), featureConfig.ExpireInSeconds, user.ID, user.Role)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,8 @@
+// Package locales contains message catalogs of public error messages
+// keyed by terrors reason. Each `${locale}.json` file is a catalog.
+package locales
+
+import "embed"
+
+//go:embed *.json
+var FS embed.FS
@@ -0,0 +1,149 @@
+package ratelimitstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/Dionid/go-boiler/dbs/maindb"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/sqli"
+	"github.com/jmoiron/sqlx"
+)
+
+// Postgres keeps state in `rate_limit` table, so limits are shared by replicas
+type Postgres struct {
+	DB *sqlx.DB
+}
+
+func NewPostgres(db *sqlx.DB) *Postgres {
+	return &Postgres{DB: db}
+}
+
+// Take locks row of the key, so concurrent requests of replicas are applied one by one
+func (s *Postgres) Take(ctx context.Context, key string, rule ratelimit.Rule, now time.Time) (ratelimit.Result, error) {
+	tx, err := s.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	defer tx.Rollback()
+
+	// # Zero state row of new key, Rule.Take treats it as fresh
+	insert, err := sqli.Query(
+		sqli.INSERT_INTO(
+			maindb.RateLimit,
+			maindb.RateLimit.Key,
+			maindb.RateLimit.Value,
+			maindb.RateLimit.Previous,
+			maindb.RateLimit.At,
+			maindb.RateLimit.ExpiresAt,
+		),
+		sqli.VALUES(
+			sqli.ValueSet(
+				sqli.VALUE(maindb.RateLimit.Key, key),
+				sqli.VALUE(maindb.RateLimit.Value, 0),
+				sqli.VALUE(maindb.RateLimit.Previous, 0),
+				sqli.VALUE(maindb.RateLimit.At, time.Time{}),
+				sqli.VALUE(maindb.RateLimit.ExpiresAt, now.Add(2*rule.Period)),
+			),
+		),
+		sqli.NewStatement("ON CONFLICT DO NOTHING"),
+	)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, insert.SQL, insert.Args...); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	selectQuery, err := sqli.Query(
+		sqli.SELECT(
+			maindb.RateLimit.AllColumns(),
+		),
+		sqli.FROM(maindb.RateLimit),
+		sqli.WHERE(
+			sqli.EQUAL(maindb.RateLimit.Key, key),
+		),
+		sqli.NewStatement("FOR UPDATE"),
+	)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	stored := &maindb.RateLimitModel{}
+	err = tx.QueryRowxContext(ctx, selectQuery.SQL, selectQuery.Args...).Scan(
+		&stored.Key,
+		&stored.Value,
+		&stored.Previous,
+		&stored.At,
+		&stored.ExpiresAt,
+	)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	state, result := rule.Take(ratelimit.State{Value: stored.Value, Previous: stored.Previous, At: stored.At}, now)
+
+	update, err := sqli.Query(
+		sqli.UPDATE(maindb.RateLimit),
+		sqli.SET(
+			sqli.SET_VALUE(maindb.RateLimit.Value, state.Value),
+			sqli.SET_VALUE(maindb.RateLimit.Previous, state.Previous),
+			sqli.SET_VALUE(maindb.RateLimit.At, state.At),
+			sqli.SET_VALUE(maindb.RateLimit.ExpiresAt, rule.Expires(state)),
+		),
+		sqli.WHERE(
+			sqli.EQUAL(maindb.RateLimit.Key, key),
+		),
+	)
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, update.SQL, update.Args...); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	return result, nil
+}
+
+// DeleteExpired removes keys which state is already forgotten by rules
+func (s *Postgres) DeleteExpired(ctx context.Context) (int64, error) {
+	query, err := sqli.Query(
+		sqli.DELETE_FROM(maindb.RateLimit),
+		sqli.WHERE(
+			sqli.LESS(maindb.RateLimit.ExpiresAt, time.Now()),
+		),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.DB.ExecContext(ctx, query.SQL, query.Args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// Cleanup deletes expired keys every interval until ctx is done
+func (s *Postgres) Cleanup(ctx context.Context, interval time.Duration, onError func(err error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.DeleteExpired(ctx); err != nil {
+				onError(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package ratelimitstore_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	inttests "github.com/Dionid/go-boiler/internal/int-tests"
+	"github.com/Dionid/go-boiler/internal/ratelimitstore"
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntPostgres(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testDeps, err := inttests.InitTestDeps(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		err := testDeps.Cleanup()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	store := ratelimitstore.NewPostgres(testDeps.Deps.MainDb)
+
+	t.Run("concurrent requests share limit", func(t *testing.T) {
+		for _, value := range []string{"*:ip:token-bucket:5/1h", "*:ip:sliding-window:5/1h"} {
+			rule, err := ratelimit.ParseRule(value)
+			assert.Nil(t, err)
+			key := uuid.NewString()
+
+			allowed := atomic.Int32{}
+			wg := sync.WaitGroup{}
+			for range 8 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					result, err := store.Take(ctx, key, rule, time.Now())
+					assert.Nil(t, err)
+					if result.Allowed {
+						allowed.Add(1)
+					}
+				}()
+			}
+			wg.Wait()
+
+			assert.Equal(t, int32(5), allowed.Load(), value)
+		}
+	})
+
+	t.Run("expired keys are deleted", func(t *testing.T) {
+		rule, err := ratelimit.ParseRule("*:ip:token-bucket:1/1ms")
+		assert.Nil(t, err)
+
+		_, err = store.Take(ctx, uuid.NewString(), rule, time.Now().Add(-time.Second))
+		assert.Nil(t, err)
+
+		deleted, err := store.DeleteExpired(ctx)
+		assert.Nil(t, err)
+		assert.GreaterOrEqual(t, deleted, int64(1))
+	})
+}
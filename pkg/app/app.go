@@ -0,0 +1,304 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Dionid/go-boiler/pkg/lifecycle"
+	"go.uber.org/zap"
+)
+
+type State string
+
+const (
+	StatePending  State = "pending"
+	StateStarting State = "starting"
+	StateRunning  State = "running"
+	StateStopping State = "stopping"
+	StateStopped  State = "stopped"
+	StateFailed   State = "failed"
+)
+
+// Component is a part of the app (DB pool, server, worker, cache)
+type Component struct {
+	Name string
+	// DependsOn are names of components that must be started before this one
+	// and stopped after it
+	DependsOn []string
+	// Start must return once component is ready, long running work goes to App.Go
+	Start func(ctx context.Context) error
+	// Stop must return when ctx is done
+	Stop func(ctx context.Context) error
+}
+
+type entry struct {
+	component Component
+	state     State
+	err       error
+	stopping  bool
+	// wg tracks goroutines started by App.Go
+	wg sync.WaitGroup
+}
+
+// App starts components in dependency order and stops them in reverse
+type App struct {
+	logger *zap.Logger
+
+	mu         sync.RWMutex
+	components map[string]*entry
+	names      []string
+	started    []string
+
+	failures chan error
+}
+
+func New(logger *zap.Logger) *App {
+	return &App{
+		logger:     logger,
+		components: map[string]*entry{},
+		failures:   make(chan error, 1),
+	}
+}
+
+// Register adds component. Registering the same name twice panics.
+func (a *App) Register(component Component) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.components[component.Name]; ok {
+		panic(fmt.Sprintf("app: component %s is already registered", component.Name))
+	}
+
+	a.components[component.Name] = &entry{
+		component: component,
+		state:     StatePending,
+	}
+	a.names = append(a.names, component.Name)
+}
+
+// order sorts components topologically, keeping registration order between independent ones
+func (a *App) order() ([]string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	visited := map[string]bool{}
+	visiting := map[string]bool{}
+	result := make([]string, 0, len(a.names))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		if visited[name] {
+			return nil
+		}
+		if visiting[name] {
+			return fmt.Errorf("dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+
+		e, ok := a.components[name]
+		if !ok {
+			return fmt.Errorf("%s depends on unknown component %s", path[len(path)-1], name)
+		}
+
+		visiting[name] = true
+		for _, dependency := range e.component.DependsOn {
+			if err := visit(dependency, append(path, name)); err != nil {
+				return err
+			}
+		}
+		visiting[name] = false
+		visited[name] = true
+
+		result = append(result, name)
+
+		return nil
+	}
+
+	for _, name := range a.names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func (a *App) setState(name string, state State, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	e := a.components[name]
+	e.state = state
+	e.err = err
+}
+
+func (a *App) state(name string) State {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.components[name].state
+}
+
+// Start starts components in dependency order.
+// If one fails, already started are stopped in reverse order.
+func (a *App) Start(ctx context.Context) error {
+	order, err := a.order()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		a.mu.RLock()
+		component := a.components[name].component
+		a.mu.RUnlock()
+
+		a.setState(name, StateStarting, nil)
+
+		if component.Start != nil {
+			if err := component.Start(ctx); err != nil {
+				a.setState(name, StateFailed, err)
+				a.logger.Error("Component failed to start", zap.String("component", name), zap.Error(err))
+
+				if stopErr := a.Stop(ctx); stopErr != nil {
+					a.logger.Error("Stop after failed start", zap.Error(stopErr))
+				}
+
+				return fmt.Errorf("start %s: %w", name, err)
+			}
+		}
+
+		a.mu.Lock()
+		a.started = append(a.started, name)
+		a.mu.Unlock()
+
+		a.setState(name, StateRunning, nil)
+		a.logger.Info("Component started", zap.String("component", name))
+	}
+
+	return nil
+}
+
+// Go runs long running work of the component (serve loop, worker).
+// Stop of the component waits for it. Error returned while component
+// is running marks it failed and is sent to Failed.
+func (a *App) Go(name string, run func() error) {
+	a.mu.RLock()
+	e, ok := a.components[name]
+	a.mu.RUnlock()
+
+	if !ok {
+		panic(fmt.Sprintf("app: component %s is not registered", name))
+	}
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+
+		err := run()
+		if err == nil {
+			return
+		}
+
+		a.mu.RLock()
+		stopping := e.stopping
+		a.mu.RUnlock()
+		if stopping {
+			return
+		}
+
+		a.setState(name, StateFailed, err)
+		a.logger.Error("Component failed", zap.String("component", name), zap.Error(err))
+
+		select {
+		case a.failures <- fmt.Errorf("%s: %w", name, err):
+		default:
+		}
+	}()
+}
+
+// Failed receives the first failure of a running component
+func (a *App) Failed() <-chan error {
+	return a.failures
+}
+
+// Stop stops started components in reverse order sharing ctx deadline
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	started := a.started
+	a.started = nil
+	a.mu.Unlock()
+
+	lc := lifecycle.New(a.logger)
+
+	for i := len(started) - 1; i >= 0; i-- {
+		name := started[i]
+
+		a.mu.RLock()
+		e := a.components[name]
+		a.mu.RUnlock()
+
+		lc.OnShutdown(name, func(ctx context.Context) error {
+			a.mu.Lock()
+			e.stopping = true
+			if e.state != StateFailed {
+				e.state = StateStopping
+			}
+			a.mu.Unlock()
+
+			if e.component.Stop != nil {
+				if err := e.component.Stop(ctx); err != nil {
+					a.setState(name, StateFailed, err)
+					return err
+				}
+			}
+
+			if err := lifecycle.Wait(ctx, &e.wg); err != nil {
+				return err
+			}
+
+			if a.state(name) != StateFailed {
+				a.setState(name, StateStopped, nil)
+			}
+
+			return nil
+		})
+	}
+
+	return lc.Shutdown(ctx)
+}
+
+// Status returns state of every component
+func (a *App) Status() map[string]State {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	status := make(map[string]State, len(a.components))
+	for name, e := range a.components {
+		status[name] = e.state
+	}
+
+	return status
+}
+
+// Check returns error if some component failed, use it as liveness check
+func (a *App) Check(ctx context.Context) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	failed := []string{}
+	for name, e := range a.components {
+		if e.state == StateFailed {
+			failed = append(failed, fmt.Sprintf("%s: %v", name, e.err))
+		}
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	sort.Strings(failed)
+
+	return fmt.Errorf("failed components: %s", strings.Join(failed, "; "))
+}
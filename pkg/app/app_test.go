@@ -0,0 +1,132 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/app"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) component(name string, dependsOn ...string) app.Component {
+	return app.Component{
+		Name:      name,
+		DependsOn: dependsOn,
+		Start: func(ctx context.Context) error {
+			r.add("start " + name)
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			r.add("stop " + name)
+			return nil
+		},
+	}
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func TestUnitApp(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dependency order", func(t *testing.T) {
+		r := &recorder{}
+		a := app.New(zap.NewNop())
+
+		a.Register(r.component("server", "db", "cache"))
+		a.Register(r.component("cache"))
+		a.Register(r.component("db", "tracing"))
+		a.Register(r.component("tracing"))
+
+		assert.Nil(t, a.Start(ctx))
+		assert.Equal(t, app.StateRunning, a.Status()["server"])
+
+		assert.Nil(t, a.Stop(ctx))
+		assert.Equal(t, app.StateStopped, a.Status()["server"])
+
+		assert.Equal(t, []string{
+			"start tracing", "start db", "start cache", "start server",
+			"stop server", "stop cache", "stop db", "stop tracing",
+		}, r.events)
+	})
+
+	t.Run("cycle and unknown dependency", func(t *testing.T) {
+		a := app.New(zap.NewNop())
+		a.Register(app.Component{Name: "a", DependsOn: []string{"b"}})
+		a.Register(app.Component{Name: "b", DependsOn: []string{"a"}})
+		assert.ErrorContains(t, a.Start(ctx), "dependency cycle: a -> b -> a")
+
+		a = app.New(zap.NewNop())
+		a.Register(app.Component{Name: "a", DependsOn: []string{"b"}})
+		assert.ErrorContains(t, a.Start(ctx), "a depends on unknown component b")
+	})
+
+	t.Run("failed start stops started", func(t *testing.T) {
+		r := &recorder{}
+		a := app.New(zap.NewNop())
+
+		a.Register(r.component("db"))
+		a.Register(app.Component{
+			Name:      "server",
+			DependsOn: []string{"db"},
+			Start: func(ctx context.Context) error {
+				return errors.New("address already in use")
+			},
+		})
+
+		assert.ErrorContains(t, a.Start(ctx), "start server: address already in use")
+		assert.Equal(t, []string{"start db", "stop db"}, r.events)
+		assert.Equal(t, app.StateFailed, a.Status()["server"])
+	})
+
+	t.Run("running component fails", func(t *testing.T) {
+		a := app.New(zap.NewNop())
+		stop := make(chan struct{})
+
+		a.Register(app.Component{
+			Name: "worker",
+			Start: func(ctx context.Context) error {
+				a.Go("worker", func() error {
+					return errors.New("queue closed")
+				})
+				a.Go("worker", func() error {
+					<-stop
+					return errors.New("stopped")
+				})
+				return nil
+			},
+			Stop: func(ctx context.Context) error {
+				close(stop)
+				return nil
+			},
+		})
+
+		assert.Nil(t, a.Start(ctx))
+
+		select {
+		case err := <-a.Failed():
+			assert.ErrorContains(t, err, "worker: queue closed")
+		case <-time.After(time.Second):
+			t.Fatal("failure is not reported")
+		}
+
+		assert.ErrorContains(t, a.Check(ctx), "worker: queue closed")
+
+		// # Error returned after stop is not reported again
+		assert.Nil(t, a.Stop(ctx))
+		assert.Equal(t, app.StateFailed, a.Status()["worker"])
+		assert.Len(t, a.Failed(), 0)
+	})
+}
@@ -0,0 +1,183 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const reloadDebounce = 200 * time.Millisecond
+
+// ParseClientAuth maps TLS_CLIENT_AUTH values to tls.ClientAuthType
+func ParseClientAuth(value string) (tls.ClientAuthType, error) {
+	switch value {
+	case "", "none":
+		return tls.NoClientCert, nil
+	case "request":
+		return tls.RequestClientCert, nil
+	case "require":
+		return tls.RequireAnyClientCert, nil
+	case "verify_if_given":
+		return tls.VerifyClientCertIfGiven, nil
+	case "require_and_verify":
+		return tls.RequireAndVerifyClientCert, nil
+	}
+
+	return tls.NoClientCert, fmt.Errorf("unknown client auth: %s", value)
+}
+
+// Reloader keeps server certificate and client CA pool in sync with files
+type Reloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	cert      atomic.Pointer[tls.Certificate]
+	clientCAs atomic.Pointer[x509.CertPool]
+}
+
+// NewReloader loads certificate, key and optional client CA bundle
+func NewReloader(certFile string, keyFile string, clientCAFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload re-reads files, on error previous certificate is kept
+func (r *Reloader) Reload() error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		pem, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return fmt.Errorf("load client CA: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("load client CA: no certificates in %s", r.clientCAFile)
+		}
+	}
+
+	r.cert.Store(&cert)
+	r.clientCAs.Store(clientCAs)
+
+	return nil
+}
+
+// ServerConfig returns config that picks up reloaded certificate and client CAs
+// on every handshake.
+//
+// ALPN selects http/1.1 for clients that offer it (browsers, curl, REST clients)
+// and h2 only for h2-only clients (gRPC, Connect over HTTP/2), cmux routes the latter
+// by content-type of their headers.
+func (r *Reloader) ServerConfig(clientAuth tls.ClientAuthType) *tls.Config {
+	base := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: []string{"h2", "http/1.1"},
+		ClientAuth: clientAuth,
+	}
+
+	config := base.Clone()
+	config.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		current := base.Clone()
+		current.Certificates = []tls.Certificate{*r.cert.Load()}
+		current.ClientCAs = r.clientCAs.Load()
+		current.NextProtos = []string{SelectProto(hello.SupportedProtos)}
+		return current, nil
+	}
+
+	return config
+}
+
+// SelectProto prefers http/1.1, so only h2-only clients speak h2
+func SelectProto(supported []string) string {
+	if len(supported) == 0 || slices.Contains(supported, "http/1.1") {
+		return "http/1.1"
+	}
+
+	return "h2"
+}
+
+func (r *Reloader) files() []string {
+	files := []string{}
+
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+
+		if abs, err := filepath.Abs(file); err == nil {
+			files = append(files, abs)
+		}
+	}
+
+	return files
+}
+
+// Watch reloads files on change until ctx is done, onReload is called
+// with result of every reload
+func (r *Reloader) Watch(ctx context.Context, onReload func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	// # Watch dirs, cert-manager and k8s Secrets replace files instead of writing them
+	files := r.files()
+	dirs := map[string]bool{}
+	for _, file := range files {
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+
+	debounce := time.NewTimer(reloadDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-watcher.Events:
+			// # k8s swaps ..data symlink, so any event in the dir may change files
+			if slices.Contains(files, event.Name) || filepath.Base(event.Name) == "..data" {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-watcher.Errors:
+			if onReload != nil {
+				onReload(err)
+			}
+		case <-debounce.C:
+			err := r.Reload()
+			if onReload != nil {
+				onReload(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package certs_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/certs"
+	"github.com/labstack/echo/v4"
+	"github.com/soheilhy/cmux"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+	kpem []byte
+}
+
+// issue creates certificate signed by parent, self-signed when parent is nil
+func issue(t *testing.T, commonName string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	assert.Nil(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	assert.Nil(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.Nil(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.Nil(t, err)
+
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		kpem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}),
+	}
+}
+
+func (c *testCert) tls(t *testing.T) tls.Certificate {
+	cert, err := tls.X509KeyPair(c.pem, c.kpem)
+	assert.Nil(t, err)
+	return cert
+}
+
+func TestUnitCerts(t *testing.T) {
+	ca := issue(t, "test-ca", nil)
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+
+	writeServerCert := func(server *testCert) {
+		assert.Nil(t, os.WriteFile(certFile, server.pem, 0600))
+		assert.Nil(t, os.WriteFile(keyFile, server.kpem, 0600))
+	}
+	writeServerCert(issue(t, "localhost", ca))
+	assert.Nil(t, os.WriteFile(caFile, ca.pem, 0600))
+
+	reloader, err := certs.NewReloader(certFile, keyFile, caFile)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloaded := make(chan error, 1)
+	go reloader.Watch(ctx, func(err error) { reloaded <- err })
+
+	// # Server: TLS listener, cmux, gRPC and echo like cmd/core
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	clientAuth, err := certs.ParseClientAuth("verify_if_given")
+	assert.Nil(t, err)
+	m := cmux.New(tls.NewListener(l, reloader.ServerConfig(clientAuth)))
+	httpL := m.Match(cmux.HTTP1Fast())
+	grpcL := m.Match(cmux.HTTP2())
+
+	var grpcIdentity *certs.Identity
+	grpcServer := grpc.NewServer(
+		grpc.Creds(certs.Credentials()),
+		grpc.ChainUnaryInterceptor(
+			certs.UnaryServerInterceptor(),
+			func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				grpcIdentity, _ = certs.IdentityFromContext(ctx)
+				return handler(ctx, req)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
+
+	e := echo.New()
+	e.HideBanner, e.HidePort = true, true
+	e.Use(certs.EchoMiddleware())
+	e.GET("/", func(c echo.Context) error {
+		identity, ok := certs.IdentityFromContext(c.Request().Context())
+		if !ok {
+			return c.String(http.StatusOK, c.Scheme()+" anonymous")
+		}
+		return c.String(http.StatusOK, c.Scheme()+" "+identity.Subject)
+	})
+	e.Listener = httpL
+	e.Server.ConnContext = certs.ConnContext
+
+	go e.Start("")
+	go grpcServer.Serve(grpcL)
+	go m.Serve()
+	defer grpcServer.Stop()
+	defer e.Close()
+
+	address := l.Addr().String()
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	client := issue(t, "billing", ca)
+
+	get := func(certificates ...tls.Certificate) (string, string) {
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, ServerName: "localhost", Certificates: certificates}, ForceAttemptHTTP2: true}
+		defer transport.CloseIdleConnections()
+
+		response, err := (&http.Client{Transport: transport}).Get("https://" + address + "/")
+		assert.Nil(t, err)
+		defer response.Body.Close()
+
+		body, err := io.ReadAll(response.Body)
+		assert.Nil(t, err)
+
+		return response.Proto, string(body)
+	}
+
+	t.Run("http prefers http/1.1 and sees identity", func(t *testing.T) {
+		proto, body := get(client.tls(t))
+		assert.Equal(t, "HTTP/1.1", proto)
+		assert.Equal(t, "https billing", body)
+
+		_, body = get()
+		assert.Equal(t, "https anonymous", body)
+	})
+
+	t.Run("grpc negotiates h2 and sees identity", func(t *testing.T) {
+		conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			RootCAs:      rootCAs,
+			ServerName:   "localhost",
+			Certificates: []tls.Certificate{client.tls(t)},
+		})))
+		assert.Nil(t, err)
+		defer conn.Close()
+
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, "billing", grpcIdentity.Subject)
+		assert.Equal(t, []string{"billing"}, grpcIdentity.DNSNames)
+	})
+
+	t.Run("untrusted client certificate is rejected", func(t *testing.T) {
+		stranger := issue(t, "stranger", issue(t, "other-ca", nil))
+		strangerCert := stranger.tls(t)
+
+		// # GetClientCertificate sends certificate even if server CAs don't match
+		transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, ServerName: "localhost", GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return &strangerCert, nil
+		}}}
+		defer transport.CloseIdleConnections()
+
+		_, err := (&http.Client{Transport: transport}).Get("https://" + address + "/")
+		assert.NotNil(t, err)
+	})
+
+	t.Run("certificate is reloaded on file change", func(t *testing.T) {
+		next := issue(t, "localhost", ca)
+		writeServerCert(next)
+
+		select {
+		case err := <-reloaded:
+			assert.Nil(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("certificate was not reloaded")
+		}
+
+		conn, err := tls.Dial("tcp", address, &tls.Config{RootCAs: rootCAs, ServerName: "localhost"})
+		assert.Nil(t, err)
+		defer conn.Close()
+		assert.Equal(t, next.cert.SerialNumber, conn.ConnectionState().PeerCertificates[0].SerialNumber)
+	})
+}
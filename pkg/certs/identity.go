@@ -0,0 +1,287 @@
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Metadata keys used to forward identity from gateway to gRPC over in-process connection
+const (
+	MetadataSubject  = "x-client-cert-subject"
+	MetadataDNSNames = "x-client-cert-dns"
+	MetadataURIs     = "x-client-cert-uri"
+)
+
+// IsMetadataKey reports identity keys, gateway must not forward them from client headers
+func IsMetadataKey(key string) bool {
+	switch strings.ToLower(key) {
+	case MetadataSubject, MetadataDNSNames, MetadataURIs:
+		return true
+	}
+	return false
+}
+
+// Identity of mTLS client, only certificates verified against client CAs produce it
+type Identity struct {
+	Subject  string
+	DNSNames []string
+	// URIs are URI SANs, e.g. SPIFFE IDs
+	URIs []string
+}
+
+// FromConnectionState returns identity of verified client certificate
+func FromConnectionState(state *tls.ConnectionState) (*Identity, bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.PeerCertificates) == 0 {
+		return nil, false
+	}
+
+	cert := state.PeerCertificates[0]
+	identity := &Identity{
+		Subject:  cert.Subject.CommonName,
+		DNSNames: cert.DNSNames,
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+
+	return identity, true
+}
+
+type identityKey struct{}
+type connectionStateKey struct{}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// IdentityFromContext returns identity of mTLS client that made the call
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// tlsConn unwraps connection accepted through cmux from TLS listener
+func tlsConn(conn net.Conn) (*tls.Conn, bool) {
+	for {
+		switch c := conn.(type) {
+		case *tls.Conn:
+			return c, true
+		case *cmux.MuxConn:
+			conn = c.Conn
+		case interface{ NetConn() net.Conn }:
+			// ## Wrappers of listener expose conn like tls.Conn does
+			conn = c.NetConn()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// # HTTP
+
+// ConnContext is http.Server ConnContext, it keeps TLS state of connection,
+// which http.Server can't see behind cmux
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	c, ok := tlsConn(conn)
+	if !ok {
+		return ctx
+	}
+
+	state := c.ConnectionState()
+	ctx = context.WithValue(ctx, connectionStateKey{}, &state)
+
+	if identity, ok := FromConnectionState(&state); ok {
+		ctx = WithIdentity(ctx, identity)
+	}
+
+	return ctx
+}
+
+// EchoMiddleware sets Request.TLS from ConnContext, so echo and gateway
+// see request as https
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if state, ok := c.Request().Context().Value(connectionStateKey{}).(*tls.ConnectionState); ok && c.Request().TLS == nil {
+				c.Request().TLS = state
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Metadata forwards identity of HTTP request to gRPC, ctx is request context
+func Metadata(ctx context.Context) metadata.MD {
+	identity, ok := IdentityFromContext(ctx)
+	if !ok {
+		return metadata.MD{}
+	}
+
+	md := metadata.Pairs(MetadataSubject, identity.Subject)
+	md.Append(MetadataDNSNames, identity.DNSNames...)
+	md.Append(MetadataURIs, identity.URIs...)
+
+	return md
+}
+
+// # gRPC
+
+// inProcessInfo marks connections from TrustedListener
+type inProcessInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (inProcessInfo) AuthType() string {
+	return "in-process"
+}
+
+type trustedConn struct {
+	net.Conn
+}
+
+type trustedListener struct {
+	net.Listener
+}
+
+func (l trustedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return trustedConn{conn}, nil
+}
+
+// TrustedListener marks in-process connections (gateway), identity metadata
+// is accepted only from them
+func TrustedListener(l net.Listener) net.Listener {
+	return trustedListener{l}
+}
+
+// IsInProcess reports if gRPC call came through TrustedListener, so its metadata
+// is set by bridges of this process rather than by client
+func IsInProcess(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	_, ok = p.AuthInfo.(inProcessInfo)
+	return ok
+}
+
+type serverCredentials struct{}
+
+// Credentials are gRPC server credentials for connections already TLS terminated
+// by listener under cmux. They expose TLS state as credentials.TLSInfo.
+func Credentials() credentials.TransportCredentials {
+	return serverCredentials{}
+}
+
+func (serverCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return conn, nil, nil
+}
+
+func (serverCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	if _, ok := conn.(trustedConn); ok {
+		return conn, inProcessInfo{credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity}}, nil
+	}
+
+	if c, ok := tlsConn(conn); ok {
+		return conn, credentials.TLSInfo{
+			State:          c.ConnectionState(),
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+		}, nil
+	}
+
+	return conn, insecureInfo{credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity}}, nil
+}
+
+type insecureInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (insecureInfo) AuthType() string {
+	return "insecure"
+}
+
+func (serverCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c serverCredentials) Clone() credentials.TransportCredentials {
+	return c
+}
+
+func (serverCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// identityFromPeer reads identity from TLS state or from metadata of in-process connection
+func identityFromPeer(ctx context.Context) (*Identity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	switch info := p.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		return FromConnectionState(&info.State)
+	case inProcessInfo:
+		md, _ := metadata.FromIncomingContext(ctx)
+		subject := md.Get(MetadataSubject)
+		if len(subject) == 0 {
+			return nil, false
+		}
+
+		return &Identity{
+			Subject:  subject[0],
+			DNSNames: md.Get(MetadataDNSNames),
+			URIs:     md.Get(MetadataURIs),
+		}, true
+	}
+
+	return nil, false
+}
+
+// UnaryServerInterceptor puts identity of mTLS client to context
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if identity, ok := identityFromPeer(ctx); ok {
+			ctx = WithIdentity(ctx, identity)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor puts identity of mTLS client to stream context
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		identity, ok := identityFromPeer(ss.Context())
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		return handler(srv, &serverStream{ss, WithIdentity(ss.Context(), identity)})
+	}
+}
@@ -0,0 +1,300 @@
+package compress
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/klauspost/compress/zstd"
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	EncodingGzip = "gzip"
+	EncodingZstd = "zstd"
+)
+
+type Config struct {
+	// MinLength is response size from which it is compressed, smaller responses aren't worth it
+	MinLength int
+	// MaxRequestBytes limits decompressed request body
+	MaxRequestBytes int64
+	// Skipper excludes routes that negotiate compression themselves or can't be buffered
+	Skipper func(c echo.Context) bool
+}
+
+// # Encoders are reused, zstd one is expensive to create
+
+var gzipWriters = sync.Pool{New: func() any { return gzip.NewWriter(io.Discard) }}
+
+var zstdWriters = sync.Pool{New: func() any {
+	w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+	return w
+}}
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+func newEncoder(encoding string, w io.Writer) encoder {
+	var e encoder
+	if encoding == EncodingZstd {
+		e = zstdWriters.Get().(*zstd.Encoder)
+	} else {
+		e = gzipWriters.Get().(*gzip.Writer)
+	}
+
+	e.Reset(w)
+	return e
+}
+
+func releaseEncoder(encoding string, e encoder) {
+	e.Reset(io.Discard)
+	if encoding == EncodingZstd {
+		zstdWriters.Put(e)
+	} else {
+		gzipWriters.Put(e)
+	}
+}
+
+// Negotiate picks response encoding from `Accept-Encoding`: the one with highest q, zstd on tie
+func Negotiate(acceptEncoding string) string {
+	best, bestQ := "", 0.0
+
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		if name == "*" {
+			name = EncodingGzip
+		}
+		if name != EncodingGzip && name != EncodingZstd || q <= 0 {
+			continue
+		}
+
+		if q > bestQ || q == bestQ && name == EncodingZstd {
+			best, bestQ = name, q
+		}
+	}
+
+	return best
+}
+
+// decompressRequest replaces body encoded with `Content-Encoding` by decoded one
+func decompressRequest(c echo.Context, maxBytes int64) error {
+	request := c.Request()
+	encoding := strings.ToLower(strings.TrimSpace(request.Header.Get("Content-Encoding")))
+
+	var body io.ReadCloser
+	switch encoding {
+	case "", "identity":
+		return nil
+	case EncodingGzip:
+		reader, err := gzip.NewReader(request.Body)
+		if err != nil {
+			return terrors.NewValidationError("Invalid gzip body", nil).WithReason("compress.invalid_body", nil)
+		}
+		body = reader
+	case EncodingZstd:
+		reader, err := zstd.NewReader(request.Body, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return terrors.NewValidationError("Invalid zstd body", nil).WithReason("compress.invalid_body", nil)
+		}
+		body = reader.IOReadCloser()
+	default:
+		return terrors.NewPublicError(http.StatusUnsupportedMediaType, "Unsupported content encoding", "unsupported content encoding "+encoding, nil).
+			WithReason("compress.unsupported_encoding", map[string]any{"encoding": encoding})
+	}
+
+	// # Decoded size is unknown, limit protects from compression bombs
+	if maxBytes > 0 {
+		body = http.MaxBytesReader(c.Response(), body, maxBytes)
+	}
+
+	request.Body = body
+	request.ContentLength = -1
+	request.Header.Del("Content-Encoding")
+	request.Header.Del("Content-Length")
+
+	return nil
+}
+
+// EchoMiddleware decodes gzip / zstd request bodies and compresses responses
+// of at least MinLength bytes with encoding negotiated by `Accept-Encoding`
+func EchoMiddleware(config Config) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if config.Skipper != nil && config.Skipper(c) {
+				return next(c)
+			}
+
+			if err := decompressRequest(c, config.MaxRequestBytes); err != nil {
+				return err
+			}
+
+			c.Response().Header().Add("Vary", "Accept-Encoding")
+
+			encoding := Negotiate(c.Request().Header.Get("Accept-Encoding"))
+			if encoding == "" {
+				return next(c)
+			}
+
+			original := c.Response().Writer
+			writer := &responseWriter{
+				ResponseWriter: original,
+				encoding:       encoding,
+				minLength:      config.MinLength,
+				status:         http.StatusOK,
+			}
+			c.Response().Writer = writer
+			// # Error returned by handler is written by HTTPErrorHandler after middleware, to the original writer
+			defer func() {
+				writer.close()
+				c.Response().Writer = original
+			}()
+
+			return next(c)
+		}
+	}
+}
+
+// responseWriter buffers response until MinLength to decide if it's compressed
+type responseWriter struct {
+	http.ResponseWriter
+	encoding  string
+	minLength int
+
+	status      int
+	wroteHeader bool
+	buffer      []byte
+	decided     bool
+	hijacked    bool
+	compression encoder
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status, w.wroteHeader = status, true
+}
+
+// decide writes header and buffered body, compressed or not
+func (w *responseWriter) decide(compress bool) error {
+	w.decided = true
+	header := w.Header()
+
+	// # Handler can encode body itself, bodyless responses have nothing to compress
+	if header.Get("Content-Encoding") != "" || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		compress = false
+	}
+
+	if compress {
+		header.Del("Content-Length")
+		header.Set("Content-Encoding", w.encoding)
+		w.compression = newEncoder(w.encoding, w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+
+	_, err := w.write(buffer)
+	return err
+}
+
+func (w *responseWriter) write(b []byte) (int, error) {
+	if w.compression != nil {
+		return w.compression.Write(b)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		return w.write(b)
+	}
+
+	w.buffer = append(w.buffer, b...)
+	if len(w.buffer) >= w.minLength {
+		if err := w.decide(true); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(b), nil
+}
+
+// Flush of streaming response starts compression before MinLength is reached
+func (w *responseWriter) Flush() {
+	if !w.decided {
+		w.decide(len(w.buffer) > 0)
+	}
+
+	if w.compression != nil {
+		w.compression.Flush()
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer doesn't support hijacking")
+	}
+
+	w.decided, w.hijacked = true, true
+	return hijacker.Hijack()
+}
+
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *responseWriter) close() {
+	if w.hijacked {
+		return
+	}
+
+	if !w.decided {
+		// # Nothing is written, so status isn't sent and error handler can still send its own
+		if !w.wroteHeader && len(w.buffer) == 0 {
+			return
+		}
+		w.decide(false)
+	}
+
+	if w.compression != nil {
+		w.compression.Close()
+		releaseEncoder(w.encoding, w.compression)
+		w.compression = nil
+	}
+}
@@ -0,0 +1,207 @@
+package compress_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/compress"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/klauspost/compress/zstd"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestUnitNegotiate(t *testing.T) {
+	for acceptEncoding, expected := range map[string]string{
+		"":                          "",
+		"identity":                  "",
+		"gzip":                      "gzip",
+		"gzip, deflate, br, zstd":   "zstd",
+		"zstd;q=0.5, gzip":          "gzip",
+		"zstd;q=0, gzip;q=0":        "",
+		"*":                         "gzip",
+		"GZIP;q=0.8, zstd;q=bad":    "gzip",
+		" br , zstd ; q=0.9 , gzip": "gzip",
+	} {
+		assert.Equal(t, expected, compress.Negotiate(acceptEncoding), acceptEncoding)
+	}
+}
+
+func newEcho(body string) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = func(err error, c echo.Context) {
+		if httpErr, ok := err.(*echo.HTTPError); ok {
+			c.NoContent(httpErr.Code)
+			return
+		}
+		c.NoContent(err.(terrors.Error).GetCode())
+	}
+	e.Use(compress.EchoMiddleware(compress.Config{MinLength: 10, MaxRequestBytes: 20}))
+	e.POST("/", func(c echo.Context) error {
+		request, err := io.ReadAll(c.Request().Body)
+		if err != nil {
+			return terrors.NewValidationError("Too large", nil)
+		}
+		return c.String(http.StatusOK, body+string(request))
+	})
+	e.GET("/empty", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	})
+	e.GET("/fail", func(c echo.Context) error {
+		return terrors.NewUnauthorizedError("Token is required", nil)
+	})
+
+	return e
+}
+
+func TestUnitEchoMiddleware(t *testing.T) {
+	serve := func(e *echo.Echo, request *http.Request) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, request)
+		return rec
+	}
+
+	t.Run("large response is compressed", func(t *testing.T) {
+		body := strings.Repeat("a", 100)
+
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		rec := serve(newEcho(body), request)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+
+		reader, err := gzip.NewReader(rec.Body)
+		assert.Nil(t, err)
+		decoded, err := io.ReadAll(reader)
+		assert.Nil(t, err)
+		assert.Equal(t, body, string(decoded))
+
+		request = httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("Accept-Encoding", "zstd")
+		rec = serve(newEcho(body), request)
+		assert.Equal(t, "zstd", rec.Header().Get("Content-Encoding"))
+
+		decoder, err := zstd.NewReader(rec.Body)
+		assert.Nil(t, err)
+		defer decoder.Close()
+		decoded, err = io.ReadAll(decoder)
+		assert.Nil(t, err)
+		assert.Equal(t, body, string(decoded))
+	})
+
+	t.Run("small and bodyless responses aren't compressed", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		rec := serve(newEcho("small"), request)
+		assert.Equal(t, "", rec.Header().Get("Content-Encoding"))
+		assert.Equal(t, "small", rec.Body.String())
+
+		request = httptest.NewRequest(http.MethodGet, "/empty", nil)
+		request.Header.Set("Accept-Encoding", "gzip")
+		rec = serve(newEcho(""), request)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "", rec.Header().Get("Content-Encoding"))
+	})
+
+	t.Run("error of handler keeps its status", func(t *testing.T) {
+		for path, status := range map[string]int{"/fail": http.StatusUnauthorized, "/unknown": http.StatusNotFound} {
+			request := httptest.NewRequest(http.MethodGet, path, nil)
+			request.Header.Set("Accept-Encoding", "gzip")
+			rec := serve(newEcho(""), request)
+			assert.Equal(t, status, rec.Code, path)
+			assert.Equal(t, "", rec.Header().Get("Content-Encoding"), path)
+		}
+	})
+
+	t.Run("request is decompressed", func(t *testing.T) {
+		compressed := &bytes.Buffer{}
+		writer, err := zstd.NewWriter(compressed)
+		assert.Nil(t, err)
+		writer.Write([]byte("hello"))
+		writer.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/", compressed)
+		request.Header.Set("Content-Encoding", "zstd")
+		rec := serve(newEcho(""), request)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "hello", rec.Body.String())
+	})
+
+	t.Run("decompressed request is limited", func(t *testing.T) {
+		compressed := &bytes.Buffer{}
+		writer := gzip.NewWriter(compressed)
+		writer.Write(bytes.Repeat([]byte("a"), 1000))
+		writer.Close()
+
+		request := httptest.NewRequest(http.MethodPost, "/", compressed)
+		request.Header.Set("Content-Encoding", "gzip")
+		rec := serve(newEcho(""), request)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("invalid and unsupported request encoding", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not gzip"))
+		request.Header.Set("Content-Encoding", "gzip")
+		rec := serve(newEcho(""), request)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader("body"))
+		request.Header.Set("Content-Encoding", "br")
+		rec = serve(newEcho(""), request)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+}
+
+func TestUnitGrpcCompressors(t *testing.T) {
+	for _, name := range []string{compress.EncodingGzip, compress.EncodingZstd} {
+		compressor := encoding.GetCompressor(name)
+		assert.NotNil(t, compressor, name)
+
+		// # Second round reuses pooled encoder and decoder
+		for range 2 {
+			compressed := &bytes.Buffer{}
+			writer, err := compressor.Compress(compressed)
+			assert.Nil(t, err)
+			writer.Write([]byte("message"))
+			assert.Nil(t, writer.Close())
+
+			reader, err := compressor.Decompress(compressed)
+			assert.Nil(t, err)
+			decoded, err := io.ReadAll(reader)
+			assert.Nil(t, err)
+			assert.Equal(t, "message", string(decoded))
+
+			// # Read after EOF doesn't return decoder to the pool again
+			_, err = reader.Read(make([]byte, 1))
+			assert.Equal(t, io.EOF, err)
+		}
+	}
+
+	t.Run("zstd decoder is pooled once", func(t *testing.T) {
+		compressor := encoding.GetCompressor(compress.EncodingZstd)
+		compressed := &bytes.Buffer{}
+		writer, err := compressor.Compress(compressed)
+		assert.Nil(t, err)
+		writer.Write([]byte("message"))
+		assert.Nil(t, writer.Close())
+
+		reader, err := compressor.Decompress(bytes.NewReader(compressed.Bytes()))
+		assert.Nil(t, err)
+		io.ReadAll(reader)
+		reader.Read(make([]byte, 1))
+		reader.Read(make([]byte, 1))
+
+		first, err := compressor.Decompress(bytes.NewReader(compressed.Bytes()))
+		assert.Nil(t, err)
+		second, err := compressor.Decompress(bytes.NewReader(compressed.Bytes()))
+		assert.Nil(t, err)
+		assert.NotSame(t, first, second)
+	})
+}
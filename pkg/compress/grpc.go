@@ -0,0 +1,84 @@
+package compress
+
+import (
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	// # Registers gzip compressor for gRPC
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// Compressors must be registered on init, so importing this package lets gRPC server
+// answer native clients that send `grpc-encoding: gzip` or `zstd` with the same compression
+func init() {
+	encoding.RegisterCompressor(&grpcZstd{})
+}
+
+type grpcZstd struct {
+	decoders sync.Pool
+}
+
+type grpcZstdWriter struct {
+	*zstd.Encoder
+}
+
+// Close finishes frame and returns encoder to the pool
+func (w *grpcZstdWriter) Close() error {
+	err := w.Encoder.Close()
+	releaseEncoder(EncodingZstd, w.Encoder)
+	return err
+}
+
+func (c *grpcZstd) Compress(w io.Writer) (io.WriteCloser, error) {
+	encoder := newEncoder(EncodingZstd, w).(*zstd.Encoder)
+	return &grpcZstdWriter{encoder}, nil
+}
+
+type grpcZstdReader struct {
+	*zstd.Decoder
+	pool *sync.Pool
+	// released is set when reader is returned to the pool, so it's returned once
+	released bool
+}
+
+// Read returns decoder to the pool when message is read or decoding failed,
+// reader that isn't read to the end is left to GC
+func (r *grpcZstdReader) Read(p []byte) (int, error) {
+	if r.released {
+		return 0, io.EOF
+	}
+
+	n, err := r.Decoder.Read(p)
+	if err != nil {
+		r.released = true
+		// # Pooled decoder must not hold the message
+		r.Decoder.Reset(nil)
+		r.pool.Put(r)
+	}
+
+	return n, err
+}
+
+func (c *grpcZstd) Decompress(r io.Reader) (io.Reader, error) {
+	if reader, ok := c.decoders.Get().(*grpcZstdReader); ok {
+		if err := reader.Reset(r); err != nil {
+			c.decoders.Put(reader)
+			return nil, err
+		}
+		reader.released = false
+		return reader, nil
+	}
+
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+
+	return &grpcZstdReader{Decoder: decoder, pool: &c.decoders}, nil
+}
+
+func (c *grpcZstd) Name() string {
+	return EncodingZstd
+}
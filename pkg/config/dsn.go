@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// # Postgres DSN is URL or lib/pq key=value connection string
+
+func isDsnUrl(dsn string) bool {
+	return strings.Contains(dsn, "://")
+}
+
+func validPostgresDsn(dsn string) bool {
+	if isDsnUrl(dsn) {
+		parsed, err := url.Parse(dsn)
+		if err != nil {
+			return false
+		}
+
+		return (parsed.Scheme == "postgres" || parsed.Scheme == "postgresql") && parsed.Host != "" && strings.TrimPrefix(parsed.Path, "/") != ""
+	}
+
+	values, err := parseKeyValueDsn(dsn)
+	if err != nil {
+		return false
+	}
+
+	return values["dbname"] != ""
+}
+
+// parseKeyValueDsn parses `host=localhost dbname=main password='it\'s secret'` like lib/pq,
+// values with spaces are single quoted, \ escapes next character
+func parseKeyValueDsn(dsn string) (map[string]string, error) {
+	values := map[string]string{}
+
+	rest := strings.TrimSpace(dsn)
+	for rest != "" {
+		key, value, ok := strings.Cut(rest, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.ContainsAny(key, " '\\") {
+			return nil, fmt.Errorf("expected key=value at %q", rest)
+		}
+
+		value = strings.TrimLeft(value, " ")
+		parsed := strings.Builder{}
+		quoted := strings.HasPrefix(value, "'")
+		if quoted {
+			value = value[1:]
+		}
+
+		i := 0
+		for ; i < len(value); i++ {
+			c := value[i]
+			if c == '\\' && i+1 < len(value) {
+				i++
+				parsed.WriteByte(value[i])
+				continue
+			}
+			if quoted && c == '\'' || !quoted && c == ' ' {
+				break
+			}
+			parsed.WriteByte(c)
+		}
+		if quoted && i == len(value) {
+			return nil, fmt.Errorf("unterminated quoted value of %s", key)
+		}
+		if quoted {
+			i++
+		}
+
+		values[key] = parsed.String()
+		rest = strings.TrimLeft(value[i:], " ")
+	}
+
+	return values, nil
+}
+
+var dsnPassword = regexp.MustCompile(`password\s*=\s*('(?:[^'\\]|\\.)*'|\S*)`)
+
+// dsnPasswordKey matches whole `password` key of key=value DSN, not e.g. `sslpassword`
+var dsnPasswordKey = regexp.MustCompile(`(?:^|\s)password\s*=\s*('(?:[^'\\]|\\.)*'|(?:[^\s\\]|\\.)*)`)
+
+// redactDsn hides password of URL or key=value DSN
+func redactDsn(dsn string) string {
+	if !isDsnUrl(dsn) {
+		return dsnPassword.ReplaceAllString(dsn, "password="+redacted)
+	}
+
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return redacted
+	}
+
+	return parsed.Redacted()
+}
+
+// SetDsnPassword replaces password of URL or key=value DSN
+func SetDsnPassword(dsn string, password string) (string, error) {
+	if !isDsnUrl(dsn) {
+		if _, err := parseKeyValueDsn(dsn); err != nil {
+			return "", err
+		}
+
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(password)
+		replacement := fmt.Sprintf("password='%s'", escaped)
+
+		if !dsnPasswordKey.MatchString(dsn) {
+			return fmt.Sprintf("%s %s", strings.TrimSpace(dsn), replacement), nil
+		}
+
+		return dsnPasswordKey.ReplaceAllStringFunc(dsn, func(match string) string {
+			// ## Keep separator before the key
+			return match[:len(match)-len(strings.TrimLeft(match, " "))] + replacement
+		}), nil
+	}
+
+	parsed, err := url.Parse(dsn)
+	if err != nil {
+		return "", err
+	}
+
+	parsed.User = url.UserPassword(parsed.User.Username(), password)
+	return parsed.String(), nil
+}
@@ -0,0 +1,189 @@
+package events
+
+import (
+	"errors"
+	"slices"
+	"sync"
+)
+
+// ErrSlowSubscriber closes subscription that didn't keep up with published events
+var ErrSlowSubscriber = errors.New("subscriber is too slow")
+
+type Event struct {
+	// Id grows with every published event, subscribers resume after it
+	Id    uint64 `json:"id"`
+	Topic string `json:"topic"`
+	Data  any    `json:"data"`
+}
+
+// Bus delivers events published by features to subscribers of the topic
+// and keeps last events in bounded replay buffer to resume after reconnect.
+// Publish never blocks: subscriber with full buffer is closed with ErrSlowSubscriber.
+// Nil *Bus is valid: publishing is no-op, subscriptions are closed right away.
+type Bus struct {
+	mu          sync.RWMutex
+	lastId      uint64
+	replay      []Event
+	replayNext  int
+	subscribers map[string]map[*Subscription]struct{}
+}
+
+// NewBus creates bus that keeps replaySize last events for resume
+func NewBus(replaySize int) *Bus {
+	return &Bus{
+		replay:      make([]Event, 0, replaySize),
+		subscribers: map[string]map[*Subscription]struct{}{},
+	}
+}
+
+// Publish sends event to current subscribers of the topic
+func (b *Bus) Publish(topic string, data any) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.lastId++
+	event := Event{Id: b.lastId, Topic: topic, Data: data}
+
+	// # Replay buffer is a ring of last events
+	if cap(b.replay) > 0 {
+		if len(b.replay) < cap(b.replay) {
+			b.replay = append(b.replay, event)
+		} else {
+			b.replay[b.replayNext] = event
+			b.replayNext = (b.replayNext + 1) % cap(b.replay)
+		}
+	}
+
+	slow := []*Subscription{}
+	for subscription := range b.subscribers[topic] {
+		select {
+		case subscription.events <- event:
+		default:
+			slow = append(slow, subscription)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, subscription := range slow {
+		subscription.close(ErrSlowSubscriber)
+	}
+}
+
+// Subscribe receives new events of topics, buffer is how many events may wait for reader
+func (b *Bus) Subscribe(topics []string, buffer int) *Subscription {
+	subscription, _ := b.SubscribeAfter(topics, buffer, b.LastId())
+	return subscription
+}
+
+// SubscribeAfter receives buffered events of topics published after lastId and then new ones.
+// Complete is false when some of events after lastId were already evicted from replay buffer
+// or lastId is from before restart.
+func (b *Bus) SubscribeAfter(topics []string, buffer int, lastId uint64) (subscription *Subscription, complete bool) {
+	if b == nil {
+		subscription = &Subscription{topics: topics, events: make(chan Event)}
+		subscription.Close()
+		return subscription, lastId == 0
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// # Oldest buffered event must follow lastId, lastId from before restart is ahead of bus
+	complete = lastId == b.lastId ||
+		(lastId < b.lastId && len(b.replay) > 0 && b.replay[b.replayNext].Id <= lastId+1)
+
+	replayed := []Event{}
+	for i := range b.replay {
+		event := b.replay[(b.replayNext+i)%len(b.replay)]
+		if event.Id > lastId && slices.Contains(topics, event.Topic) {
+			replayed = append(replayed, event)
+		}
+	}
+
+	subscription = &Subscription{
+		bus:    b,
+		topics: topics,
+		events: make(chan Event, buffer+len(replayed)),
+	}
+	for _, event := range replayed {
+		subscription.events <- event
+	}
+
+	for _, topic := range topics {
+		if b.subscribers[topic] == nil {
+			b.subscribers[topic] = map[*Subscription]struct{}{}
+		}
+		b.subscribers[topic][subscription] = struct{}{}
+	}
+
+	return subscription, complete
+}
+
+// LastId is id of last published event
+func (b *Bus) LastId() uint64 {
+	if b == nil {
+		return 0
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return b.lastId
+}
+
+type Subscription struct {
+	bus    *Bus
+	topics []string
+
+	events chan Event
+	once   sync.Once
+	err    error
+}
+
+func (s *Subscription) Topics() []string {
+	return s.topics
+}
+
+// Events is closed when subscription is closed, check Err for the reason
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Err is ErrSlowSubscriber when bus closed subscription, nil otherwise
+func (s *Subscription) Err() error {
+	if s.bus == nil {
+		return nil
+	}
+
+	s.bus.mu.RLock()
+	defer s.bus.mu.RUnlock()
+
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.close(nil)
+}
+
+func (s *Subscription) close(err error) {
+	s.once.Do(func() {
+		if s.bus == nil {
+			close(s.events)
+			return
+		}
+
+		s.bus.mu.Lock()
+		defer s.bus.mu.Unlock()
+
+		s.err = err
+		for _, topic := range s.topics {
+			delete(s.bus.subscribers[topic], s)
+			if len(s.bus.subscribers[topic]) == 0 {
+				delete(s.bus.subscribers, topic)
+			}
+		}
+		close(s.events)
+	})
+}
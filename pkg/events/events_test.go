@@ -0,0 +1,96 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitBus(t *testing.T) {
+	t.Run("delivers to topic subscribers", func(t *testing.T) {
+		bus := events.NewBus(10)
+		orders := bus.Subscribe([]string{"orders"}, 1)
+		users := bus.Subscribe([]string{"users"}, 1)
+
+		bus.Publish("orders", 1)
+
+		assert.Equal(t, events.Event{Id: 1, Topic: "orders", Data: 1}, <-orders.Events())
+		assert.Len(t, users.Events(), 0)
+	})
+
+	t.Run("closed subscription stops receiving", func(t *testing.T) {
+		bus := events.NewBus(10)
+		subscription := bus.Subscribe([]string{"orders"}, 1)
+		subscription.Close()
+		subscription.Close()
+
+		bus.Publish("orders", 1)
+
+		_, ok := <-subscription.Events()
+		assert.False(t, ok)
+		assert.Nil(t, subscription.Err())
+	})
+
+	t.Run("slow subscriber is closed", func(t *testing.T) {
+		bus := events.NewBus(10)
+		slow := bus.Subscribe([]string{"orders"}, 1)
+		fast := bus.Subscribe([]string{"orders"}, 2)
+
+		bus.Publish("orders", 1)
+		bus.Publish("orders", 2)
+
+		assert.Equal(t, 1, (<-slow.Events()).Data)
+		_, ok := <-slow.Events()
+		assert.False(t, ok)
+		assert.ErrorIs(t, slow.Err(), events.ErrSlowSubscriber)
+
+		assert.Len(t, fast.Events(), 2)
+		assert.Nil(t, fast.Err())
+	})
+
+	t.Run("resumes from replay buffer", func(t *testing.T) {
+		bus := events.NewBus(3)
+		for i := 1; i <= 4; i++ {
+			bus.Publish([]string{"orders", "users"}[i%2], i)
+		}
+
+		// # Buffer has events 2, 3, 4
+		subscription, complete := bus.SubscribeAfter([]string{"orders"}, 1, 1)
+		assert.True(t, complete)
+		assert.Equal(t, events.Event{Id: 2, Topic: "orders", Data: 2}, <-subscription.Events())
+		assert.Equal(t, events.Event{Id: 4, Topic: "orders", Data: 4}, <-subscription.Events())
+
+		bus.Publish("orders", 5)
+		assert.Equal(t, uint64(5), (<-subscription.Events()).Id)
+
+		_, complete = bus.SubscribeAfter([]string{"orders"}, 1, 5)
+		assert.True(t, complete)
+
+		// # Event 2 is evicted now
+		subscription, complete = bus.SubscribeAfter([]string{"orders"}, 1, 1)
+		assert.False(t, complete)
+		assert.Equal(t, uint64(4), (<-subscription.Events()).Id)
+
+		// # Id from before restart
+		_, complete = bus.SubscribeAfter([]string{"orders"}, 1, 100)
+		assert.False(t, complete)
+	})
+
+	t.Run("nil bus ignores publish and closes subscriptions", func(t *testing.T) {
+		var bus *events.Bus
+		bus.Publish("orders", 1)
+		assert.Equal(t, uint64(0), bus.LastId())
+
+		subscription := bus.Subscribe([]string{"orders"}, 1)
+		_, ok := <-subscription.Events()
+		assert.False(t, ok)
+		assert.Nil(t, subscription.Err())
+		subscription.Close()
+
+		subscription, complete := bus.SubscribeAfter([]string{"orders"}, 1, 5)
+		assert.False(t, complete)
+		_, ok = <-subscription.Events()
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,241 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type Status string
+
+const (
+	StatusOk           Status = "ok"
+	StatusFail         Status = "fail"
+	StatusShuttingDown Status = "shutting_down"
+)
+
+// Checker returns error if dependency is not healthy
+type Checker func(ctx context.Context) error
+
+type CheckResult struct {
+	Status     Status `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+type Report struct {
+	Status Status                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+func (r Report) Ok() bool {
+	return r.Status == StatusOk
+}
+
+// Registry holds liveness and readiness checkers and serves them
+// over HTTP (via Live / Ready) and `grpc.health.v1`.
+// Nil *Registry is valid: adding checkers is no-op and it reports ok.
+type Registry struct {
+	timeout  time.Duration
+	services []string
+
+	mu        sync.RWMutex
+	liveness  map[string]Checker
+	readiness map[string]Checker
+
+	shuttingDown atomic.Bool
+	grpcServer   *grpchealth.Server
+}
+
+// NewRegistry creates registry where every check is limited by timeout.
+// Services are gRPC service names reported by `grpc.health.v1` in addition to overall "" service.
+func NewRegistry(timeout time.Duration, services ...string) *Registry {
+	return &Registry{
+		timeout:    timeout,
+		services:   services,
+		liveness:   map[string]Checker{},
+		readiness:  map[string]Checker{},
+		grpcServer: grpchealth.NewServer(),
+	}
+}
+
+// AddLivenessCheck adds checker that restarts the process when failing,
+// so it must not depend on external services
+func (r *Registry) AddLivenessCheck(name string, checker Checker) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.liveness[name] = checker
+}
+
+// AddReadinessCheck adds checker that takes instance out of load balancing when failing
+func (r *Registry) AddReadinessCheck(name string, checker Checker) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.readiness[name] = checker
+}
+
+func (r *Registry) run(ctx context.Context, checkers map[string]Checker) Report {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusOk,
+		Checks: make(map[string]CheckResult, len(checkers)),
+	}
+
+	results := make(chan struct {
+		name   string
+		result CheckResult
+	}, len(checkers))
+
+	for name, checker := range checkers {
+		go func() {
+			checkCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := runChecker(checkCtx, checker)
+
+			result := CheckResult{
+				Status:     StatusOk,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result.Status = StatusFail
+				result.Error = err.Error()
+			}
+
+			results <- struct {
+				name   string
+				result CheckResult
+			}{name, result}
+		}()
+	}
+
+	for range checkers {
+		res := <-results
+		report.Checks[res.name] = res.result
+		if res.result.Status != StatusOk {
+			report.Status = StatusFail
+		}
+	}
+
+	return report
+}
+
+// runChecker returns checker error or ctx error if checker didn't respect ctx
+func runChecker(ctx context.Context, checker Checker) (err error) {
+	done := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- fmt.Errorf("panic: %v", rec)
+			}
+		}()
+		done <- checker(ctx)
+	}()
+
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Live reports if process is alive
+func (r *Registry) Live(ctx context.Context) Report {
+	if r == nil {
+		return Report{Status: StatusOk}
+	}
+
+	return r.run(ctx, r.liveness)
+}
+
+// Ready reports if instance can serve traffic and syncs `grpc.health.v1` statuses
+func (r *Registry) Ready(ctx context.Context) Report {
+	if r == nil {
+		return Report{Status: StatusOk}
+	}
+
+	if r.shuttingDown.Load() {
+		return Report{Status: StatusShuttingDown}
+	}
+
+	report := r.run(ctx, r.readiness)
+
+	status := healthpb.HealthCheckResponse_SERVING
+	if !report.Ok() {
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	r.setServingStatus(status)
+
+	return report
+}
+
+func (r *Registry) setServingStatus(status healthpb.HealthCheckResponse_ServingStatus) {
+	r.grpcServer.SetServingStatus("", status)
+	for _, service := range r.services {
+		r.grpcServer.SetServingStatus(service, status)
+	}
+}
+
+// Shutdown permanently flips readiness to not serving, so load balancers drain traffic
+func (r *Registry) Shutdown() {
+	if r == nil {
+		return
+	}
+
+	r.shuttingDown.Store(true)
+	r.grpcServer.Shutdown()
+}
+
+// # gRPC
+
+type grpcServer struct {
+	*grpchealth.Server
+	registry *Registry
+}
+
+// Check runs readiness checks before answering
+func (s *grpcServer) Check(ctx context.Context, request *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	s.registry.Ready(ctx)
+	return s.Server.Check(ctx, request)
+}
+
+// GRPCServer returns `grpc.health.v1` implementation to register on grpc.Server
+func (r *Registry) GRPCServer() healthpb.HealthServer {
+	r.setServingStatus(healthpb.HealthCheckResponse_SERVING)
+
+	return &grpcServer{
+		Server:   r.grpcServer,
+		registry: r,
+	}
+}
+
+// # Checkers
+
+type pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// PingChecker checks db (*sql.DB, *sqlx.DB) is reachable
+func PingChecker(db pinger) Checker {
+	return func(ctx context.Context) error {
+		return db.PingContext(ctx)
+	}
+}
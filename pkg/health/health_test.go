@@ -0,0 +1,82 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/health"
+	"github.com/stretchr/testify/assert"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestUnitHealth(t *testing.T) {
+	ctx := context.Background()
+
+	registry := health.NewRegistry(50*time.Millisecond, "test.Api")
+	grpcServer := registry.GRPCServer()
+
+	registry.AddLivenessCheck("alive", func(ctx context.Context) error {
+		return nil
+	})
+	registry.AddReadinessCheck("db", func(ctx context.Context) error {
+		return nil
+	})
+
+	t.Run("ready", func(t *testing.T) {
+		assert.True(t, registry.Live(ctx).Ok())
+
+		report := registry.Ready(ctx)
+		assert.True(t, report.Ok())
+		assert.Equal(t, health.StatusOk, report.Checks["db"].Status)
+
+		resp, err := grpcServer.Check(ctx, &healthpb.HealthCheckRequest{Service: "test.Api"})
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+	})
+
+	t.Run("failing and hanging checks", func(t *testing.T) {
+		registry.AddReadinessCheck("cache", func(ctx context.Context) error {
+			return errors.New("connection refused")
+		})
+		registry.AddReadinessCheck("queue", func(ctx context.Context) error {
+			time.Sleep(time.Second)
+			return nil
+		})
+
+		report := registry.Ready(ctx)
+		assert.Equal(t, health.StatusFail, report.Status)
+		assert.Equal(t, "connection refused", report.Checks["cache"].Error)
+		assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["queue"].Error)
+		assert.Equal(t, health.StatusOk, report.Checks["db"].Status)
+
+		resp, err := grpcServer.Check(ctx, &healthpb.HealthCheckRequest{})
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+
+		// # Liveness doesn't depend on readiness checks
+		assert.True(t, registry.Live(ctx).Ok())
+	})
+
+	t.Run("shutdown", func(t *testing.T) {
+		registry.Shutdown()
+
+		assert.Equal(t, health.StatusShuttingDown, registry.Ready(ctx).Status)
+
+		resp, err := grpcServer.Check(ctx, &healthpb.HealthCheckRequest{Service: "test.Api"})
+		assert.Nil(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.Status)
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+		var nilRegistry *health.Registry
+		nilRegistry.AddReadinessCheck("db", func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.Equal(t, health.Report{Status: health.StatusOk}, nilRegistry.Live(context.Background()))
+		assert.Equal(t, health.Report{Status: health.StatusOk}, nilRegistry.Ready(context.Background()))
+		nilRegistry.Shutdown()
+	})
+}
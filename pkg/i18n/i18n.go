@@ -0,0 +1,264 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Message is a localized message with optional plural forms.
+// In catalog file it can be a plain string or an object
+// with "zero", "one", "two", "few", "many", "other" keys.
+type Message struct {
+	Zero  string `json:"zero,omitempty"`
+	One   string `json:"one,omitempty"`
+	Two   string `json:"two,omitempty"`
+	Few   string `json:"few,omitempty"`
+	Many  string `json:"many,omitempty"`
+	Other string `json:"other,omitempty"`
+}
+
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var other string
+	if err := json.Unmarshal(data, &other); err == nil {
+		m.Other = other
+		return nil
+	}
+
+	type message Message
+
+	return json.Unmarshal(data, (*message)(m))
+}
+
+func (m Message) form(form plural.Form) string {
+	var result string
+
+	switch form {
+	case plural.Zero:
+		result = m.Zero
+	case plural.One:
+		result = m.One
+	case plural.Two:
+		result = m.Two
+	case plural.Few:
+		result = m.Few
+	case plural.Many:
+		result = m.Many
+	}
+
+	if result == "" {
+		return m.Other
+	}
+
+	return result
+}
+
+// Catalog is a set of messages of one locale keyed by reason
+type Catalog map[string]Message
+
+// Bundle holds catalogs of all supported locales
+type Bundle struct {
+	mu sync.RWMutex
+
+	defaultLocale language.Tag
+	locales       []language.Tag
+	catalogs      map[language.Tag]Catalog
+	matcher       language.Matcher
+}
+
+func NewBundle(defaultLocale language.Tag) *Bundle {
+	return &Bundle{
+		defaultLocale: defaultLocale,
+		locales:       []language.Tag{defaultLocale},
+		catalogs:      map[language.Tag]Catalog{},
+		matcher:       language.NewMatcher([]language.Tag{defaultLocale}),
+	}
+}
+
+func (b *Bundle) DefaultLocale() language.Tag {
+	return b.defaultLocale
+}
+
+// AddCatalog adds messages to locale catalog
+func (b *Bundle) AddCatalog(locale language.Tag, catalog Catalog) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	existing, ok := b.catalogs[locale]
+	if !ok {
+		existing = Catalog{}
+		b.catalogs[locale] = existing
+
+		if locale != b.defaultLocale {
+			b.locales = append(b.locales, locale)
+			b.matcher = language.NewMatcher(b.locales)
+		}
+	}
+
+	for reason, message := range catalog {
+		existing[reason] = message
+	}
+}
+
+// LoadFS loads every `${locale}.json` file in dir as a catalog
+func (b *Bundle) LoadFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		locale, err := language.Parse(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			return fmt.Errorf("catalog %s: %w", entry.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return err
+		}
+
+		catalog := Catalog{}
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			return fmt.Errorf("catalog %s: %w", entry.Name(), err)
+		}
+
+		b.AddCatalog(locale, catalog)
+	}
+
+	return nil
+}
+
+// Negotiate picks best supported locale for preferences.
+// Each preference can be a locale ("ru", "pt-BR") or Accept-Language header value.
+// Empty preferences are skipped, first one that matches wins.
+func (b *Bundle) Negotiate(preferences ...string) language.Tag {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+
+		tags, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+
+		_, index, confidence := b.matcher.Match(tags...)
+		if confidence == language.No {
+			continue
+		}
+
+		return b.locales[index]
+	}
+
+	return b.defaultLocale
+}
+
+// Localize returns message for reason in locale.
+// Fallback chain: locale -> its parents (pt-BR -> pt) -> default locale.
+// If params has integer "count", plural form is chosen by it.
+// Params are interpolated into `{name}` placeholders.
+func (b *Bundle) Localize(locale language.Tag, reason string, params map[string]any) (string, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, tag := range b.fallbackChain(locale) {
+		catalog, ok := b.catalogs[tag]
+		if !ok {
+			continue
+		}
+
+		message, ok := catalog[reason]
+		if !ok {
+			continue
+		}
+
+		text := message.Other
+		if count, ok := pluralCount(params); ok {
+			text = message.form(plural.Cardinal.MatchPlural(tag, count, 0, 0, 0, 0))
+		}
+
+		return interpolate(text, params), true
+	}
+
+	return "", false
+}
+
+// Translator returns function localizing reasons into locale
+func (b *Bundle) Translator(locale language.Tag) func(reason string, params map[string]any) (string, bool) {
+	return func(reason string, params map[string]any) (string, bool) {
+		return b.Localize(locale, reason, params)
+	}
+}
+
+func (b *Bundle) fallbackChain(locale language.Tag) []language.Tag {
+	chain := []language.Tag{}
+
+	for tag := locale; !tag.IsRoot(); tag = tag.Parent() {
+		chain = append(chain, tag)
+	}
+
+	return append(chain, b.defaultLocale)
+}
+
+func pluralCount(params map[string]any) (int, bool) {
+	switch v := params["count"].(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case uint:
+		return int(v), true
+	case uint32:
+		return int(v), true
+	case uint64:
+		return int(v), true
+	case float64:
+		return int(v), v == float64(int(v))
+	}
+
+	return 0, false
+}
+
+func interpolate(text string, params map[string]any) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", fmt.Sprint(value))
+	}
+
+	return strings.NewReplacer(replacements...).Replace(text)
+}
+
+// # Context
+
+type localeCtxKey struct{}
+
+func WithLocale(ctx context.Context, locale language.Tag) context.Context {
+	return context.WithValue(ctx, localeCtxKey{}, locale)
+}
+
+// LocaleFromContext returns locale negotiated for the request
+func LocaleFromContext(ctx context.Context) (language.Tag, bool) {
+	locale, ok := ctx.Value(localeCtxKey{}).(language.Tag)
+	return locale, ok
+}
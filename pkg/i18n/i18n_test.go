@@ -0,0 +1,63 @@
+package i18n_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/Dionid/go-boiler/pkg/i18n"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+)
+
+func TestUnitI18n(t *testing.T) {
+	bundle := i18n.NewBundle(language.English)
+	err := bundle.LoadFS(fstest.MapFS{
+		"en.json": {Data: []byte(`{
+			"validation.required": "{field} is required",
+			"attempts_left": {"one": "{count} attempt left", "other": "{count} attempts left"}
+		}`)},
+		"ru.json": {Data: []byte(`{
+			"attempts_left": {"one": "Осталась {count} попытка", "few": "Осталось {count} попытки", "many": "Осталось {count} попыток"}
+		}`)},
+	}, ".")
+	assert.Nil(t, err)
+
+	t.Run("negotiate", func(t *testing.T) {
+		assert.Equal(t, language.Russian, bundle.Negotiate("", "ru-RU,ru;q=0.9,en;q=0.8"))
+		assert.Equal(t, language.Russian, bundle.Negotiate("ru", "en"))
+		assert.Equal(t, language.English, bundle.Negotiate("de"))
+	})
+
+	t.Run("plural", func(t *testing.T) {
+		message, ok := bundle.Localize(language.Russian, "attempts_left", map[string]any{"count": 1})
+		assert.True(t, ok)
+		assert.Equal(t, "Осталась 1 попытка", message)
+
+		message, _ = bundle.Localize(language.Russian, "attempts_left", map[string]any{"count": 3})
+		assert.Equal(t, "Осталось 3 попытки", message)
+
+		message, _ = bundle.Localize(language.Russian, "attempts_left", map[string]any{"count": 5})
+		assert.Equal(t, "Осталось 5 попыток", message)
+
+		message, _ = bundle.Localize(language.English, "attempts_left", map[string]any{"count": 5})
+		assert.Equal(t, "5 attempts left", message)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		message, ok := bundle.Localize(language.MustParse("ru-RU"), "validation.required", map[string]any{"field": "email"})
+		assert.True(t, ok)
+		assert.Equal(t, "email is required", message)
+
+		_, ok = bundle.Localize(language.Russian, "unknown", nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("terrors", func(t *testing.T) {
+		err := terrors.NewValidationError("email is required", nil).WithReason("validation.required", map[string]any{"field": "Email"})
+
+		localized := terrors.Localize(err, bundle.Translator(language.Russian))
+		assert.Equal(t, "Email is required", localized.GetPublicMessage())
+		assert.Equal(t, "email is required", localized.GetPrivateMessage())
+	})
+}
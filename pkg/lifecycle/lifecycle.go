@@ -0,0 +1,100 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type stage struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+// Lifecycle runs shutdown stages one by one in the order they were added
+type Lifecycle struct {
+	logger *zap.Logger
+	stages []stage
+}
+
+func New(logger *zap.Logger) *Lifecycle {
+	return &Lifecycle{
+		logger: logger,
+	}
+}
+
+// OnShutdown adds stage. Stop must return when ctx is done.
+func (l *Lifecycle) OnShutdown(name string, stop func(ctx context.Context) error) {
+	l.stages = append(l.stages, stage{name: name, stop: stop})
+}
+
+// Shutdown runs stages sharing ctx deadline. Failed stage doesn't stop the next ones,
+// but after deadline is exceeded the rest are skipped and reported as not finished.
+func (l *Lifecycle) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	for i, s := range l.stages {
+		start := time.Now()
+
+		done := make(chan error, 1)
+		go func() {
+			done <- s.stop(ctx)
+		}()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				l.logger.Error("Shutdown stage failed", zap.String("stage", s.name), zap.Duration("duration", time.Since(start)), zap.Error(err))
+				errs = append(errs, fmt.Errorf("%s: %w", s.name, err))
+				continue
+			}
+
+			l.logger.Info("Shutdown stage finished", zap.String("stage", s.name), zap.Duration("duration", time.Since(start)))
+		case <-ctx.Done():
+			notFinished := []string{}
+			for _, rest := range l.stages[i:] {
+				notFinished = append(notFinished, rest.name)
+			}
+
+			l.logger.Error("Shutdown deadline exceeded", zap.Strings("notFinished", notFinished))
+			errs = append(errs, fmt.Errorf("%s: %w", s.name, ctx.Err()))
+
+			return errors.Join(errs...)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Wait waits for wg or returns ctx error
+func Wait(ctx context.Context, wg *sync.WaitGroup) error {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Sleep sleeps for duration or returns ctx error
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
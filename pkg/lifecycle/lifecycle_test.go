@@ -0,0 +1,65 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/lifecycle"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestUnitLifecycle(t *testing.T) {
+	t.Run("ordered stages", func(t *testing.T) {
+		lc := lifecycle.New(zap.NewNop())
+		order := []string{}
+
+		lc.OnShutdown("servers", func(ctx context.Context) error {
+			order = append(order, "servers")
+			return nil
+		})
+		lc.OnShutdown("workers", func(ctx context.Context) error {
+			order = append(order, "workers")
+			return errors.New("worker failed")
+		})
+		lc.OnShutdown("db", func(ctx context.Context) error {
+			order = append(order, "db")
+			return nil
+		})
+
+		err := lc.Shutdown(context.Background())
+		assert.ErrorContains(t, err, "workers: worker failed")
+		assert.Equal(t, []string{"servers", "workers", "db"}, order)
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		core, logs := observer.New(zap.InfoLevel)
+		lc := lifecycle.New(zap.New(core))
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+		defer wg.Done()
+
+		lc.OnShutdown("workers", func(ctx context.Context) error {
+			return lifecycle.Wait(ctx, wg)
+		})
+		lc.OnShutdown("db", func(ctx context.Context) error {
+			return nil
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := lc.Shutdown(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		entries := logs.FilterMessage("Shutdown deadline exceeded").All()
+		if assert.Len(t, entries, 1) {
+			assert.Equal(t, []interface{}{"workers", "db"}, entries[0].ContextMap()["notFinished"])
+		}
+	})
+}
@@ -0,0 +1,180 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Registry is a prometheus registry with helpers to declare metrics.
+// Nil *Registry is valid: declared metrics work, but are not exposed.
+type Registry struct {
+	registry  *prometheus.Registry
+	namespace string
+
+	grpcHandled  *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+}
+
+// NewRegistry creates registry with Go runtime and process collectors.
+// Every metric name is prefixed with namespace.
+func NewRegistry(namespace string) *Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	r := &Registry{
+		registry:  registry,
+		namespace: namespace,
+	}
+
+	r.grpcHandled = r.Counter(
+		"grpc_server_handled_total",
+		"Total number of RPCs completed on the server, regardless of success or failure.",
+		"grpc_type", "grpc_method", "grpc_code",
+	)
+	r.grpcDuration = r.Histogram(
+		"grpc_server_handling_seconds",
+		"Histogram of response latency (seconds) of RPCs handled by the server.",
+		prometheus.DefBuckets,
+		"grpc_type", "grpc_method",
+	)
+
+	return r
+}
+
+func (r *Registry) Namespace() string {
+	if r == nil {
+		return ""
+	}
+
+	return r.namespace
+}
+
+// Registerer returns registerer to use with third-party collectors
+func (r *Registry) Registerer() prometheus.Registerer {
+	if r == nil {
+		return prometheus.NewRegistry()
+	}
+
+	return r.registry
+}
+
+// Handler exposes registered metrics, nil registry has none to expose
+func (r *Registry) Handler() http.Handler {
+	if r == nil {
+		return http.NotFoundHandler()
+	}
+
+	return promhttp.InstrumentMetricHandler(
+		r.registry,
+		promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{}),
+	)
+}
+
+// register registers collector or returns already registered one with the same descriptor
+func register[C prometheus.Collector](r *Registry, collector C) C {
+	if r == nil {
+		return collector
+	}
+
+	if err := r.registry.Register(collector); err != nil {
+		var alreadyRegistered prometheus.AlreadyRegisteredError
+		if errors.As(err, &alreadyRegistered) {
+			if existing, ok := alreadyRegistered.ExistingCollector.(C); ok {
+				return existing
+			}
+		}
+
+		panic(err)
+	}
+
+	return collector
+}
+
+// Counter declares counter. Declaring the same counter twice returns the first one.
+func (r *Registry) Counter(name string, help string, labels ...string) *prometheus.CounterVec {
+	return register(r, prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: r.Namespace(),
+			Name:      name,
+			Help:      help,
+		},
+		labels,
+	))
+}
+
+// Gauge declares gauge. Declaring the same gauge twice returns the first one.
+func (r *Registry) Gauge(name string, help string, labels ...string) *prometheus.GaugeVec {
+	return register(r, prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: r.Namespace(),
+			Name:      name,
+			Help:      help,
+		},
+		labels,
+	))
+}
+
+// Histogram declares histogram. Declaring the same histogram twice returns the first one.
+func (r *Registry) Histogram(name string, help string, buckets []float64, labels ...string) *prometheus.HistogramVec {
+	return register(r, prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: r.Namespace(),
+			Name:      name,
+			Help:      help,
+			Buckets:   buckets,
+		},
+		labels,
+	))
+}
+
+// RegisterDB exposes sql.DBStats of db pool labeled by dbName
+func (r *Registry) RegisterDB(dbName string, db *sql.DB) {
+	if r == nil {
+		return
+	}
+
+	r.registry.MustRegister(collectors.NewDBStatsCollector(db, dbName))
+}
+
+// # gRPC
+
+func (r *Registry) observe(grpcType string, method string, start time.Time, err error) {
+	if r == nil {
+		return
+	}
+
+	r.grpcHandled.WithLabelValues(grpcType, method, status.Code(err).String()).Inc()
+	r.grpcDuration.WithLabelValues(grpcType, method).Observe(time.Since(start).Seconds())
+}
+
+// UnaryServerInterceptor collects rate, errors and duration per method
+func (r *Registry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		r.observe("unary", info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor collects rate, errors and duration per method
+func (r *Registry) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		r.observe("server_stream", info.FullMethod, start, err)
+		return err
+	}
+}
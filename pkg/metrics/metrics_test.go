@@ -0,0 +1,60 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitMetrics(t *testing.T) {
+	registry := metrics.NewRegistry("test")
+
+	t.Run("declare twice", func(t *testing.T) {
+		first := registry.Counter("sign_ups_total", "Sign ups", "role")
+		second := registry.Counter("sign_ups_total", "Sign ups", "role")
+
+		first.WithLabelValues("client").Inc()
+		assert.Equal(t, float64(1), testutil.ToFloat64(second.WithLabelValues("client")))
+	})
+
+	t.Run("grpc interceptor", func(t *testing.T) {
+		interceptor := registry.UnaryServerInterceptor()
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Api/Call"}
+
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, nil
+		})
+		_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		})
+
+		recorder := httptest.NewRecorder()
+		registry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		body := recorder.Body.String()
+
+		assert.True(t, strings.Contains(body, `test_grpc_server_handled_total{grpc_code="OK",grpc_method="/test.Api/Call",grpc_type="unary"} 1`))
+		assert.True(t, strings.Contains(body, `test_grpc_server_handled_total{grpc_code="NotFound",grpc_method="/test.Api/Call",grpc_type="unary"} 1`))
+		assert.True(t, strings.Contains(body, "go_goroutines"))
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+		var nilRegistry *metrics.Registry
+
+		counter := nilRegistry.Counter("sign_ups_total", "Sign ups")
+		counter.WithLabelValues().Inc()
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter.WithLabelValues()))
+
+		recorder := httptest.NewRecorder()
+		nilRegistry.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	})
+}
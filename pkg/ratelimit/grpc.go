@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Headers of https://datatracker.ietf.org/doc/draft-ietf-httpapi-ratelimit-headers/,
+// lowercase to be used as gRPC metadata keys
+const (
+	HeaderLimit      = "ratelimit-limit"
+	HeaderRemaining  = "ratelimit-remaining"
+	HeaderReset      = "ratelimit-reset"
+	HeaderRetryAfter = "retry-after"
+)
+
+// Headers returns RateLimit-* headers of result and Retry-After if request is not allowed
+func Headers(result Result) map[string]string {
+	headers := map[string]string{
+		HeaderLimit:     strconv.Itoa(result.Limit),
+		HeaderRemaining: strconv.Itoa(result.Remaining),
+		HeaderReset:     ceilSeconds(result.Reset),
+	}
+
+	if !result.Allowed {
+		headers[HeaderRetryAfter] = ceilSeconds(result.RetryAfter)
+	}
+
+	return headers
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
+
+// ExceededError is returned to request that is not allowed
+func ExceededError(result Result) terrors.Error {
+	retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+
+	return terrors.NewTooManyRequestsError("Too many requests", map[string]any{"retryAfter": retryAfter}).
+		WithReason("rate_limit.exceeded", map[string]any{"retryAfter": retryAfter})
+}
+
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+// UnaryServerInterceptor limits calls by method name (`SignIn` of `/go_boiler.calls.MainApi/SignIn`),
+// sends RateLimit-* headers and rejects exceeded calls with 429 / ResourceExhausted
+func UnaryServerInterceptor(limiter *Limiter, identify func(ctx context.Context, req any) Subject) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		result, ok := limiter.Allow(ctx, methodName(info.FullMethod), identify(ctx, req))
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		// # Best effort: fails only when there is no transport stream (direct calls)
+		_ = grpc.SetHeader(ctx, metadata.New(Headers(result)))
+
+		if !result.Allowed {
+			return nil, ExceededError(result)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	limiter  *Limiter
+	identify func(ctx context.Context, req any) Subject
+	method   string
+	received bool
+}
+
+// RecvMsg limits stream by its first message, which carries token
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.received {
+		return nil
+	}
+	s.received = true
+
+	result, ok := s.limiter.Allow(s.Context(), s.method, s.identify(s.Context(), m))
+	if !ok {
+		return nil
+	}
+
+	_ = s.SetHeader(metadata.New(Headers(result)))
+
+	if !result.Allowed {
+		return ExceededError(result)
+	}
+
+	return nil
+}
+
+// StreamServerInterceptor limits streams by method name on their first message
+func StreamServerInterceptor(limiter *Limiter, identify func(ctx context.Context, req any) Subject) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			limiter:      limiter,
+			identify:     identify,
+			method:       methodName(info.FullMethod),
+		})
+	}
+}
+
+// EchoMiddleware limits plain HTTP route as method, e.g. WebSocket upgrade
+func EchoMiddleware(limiter *Limiter, method string, identify func(c echo.Context) Subject) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			result, ok := limiter.Allow(c.Request().Context(), method, identify(c))
+			if !ok {
+				return next(c)
+			}
+
+			for key, value := range Headers(result) {
+				c.Response().Header().Set(key, value)
+			}
+
+			if !result.Allowed {
+				return ExceededError(result)
+			}
+
+			return next(c)
+		}
+	}
+}
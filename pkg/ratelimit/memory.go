@@ -0,0 +1,45 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepInterval = time.Minute
+
+type memoryEntry struct {
+	state   State
+	expires time.Time
+}
+
+// MemoryStore keeps state in process, so every replica has its own limits
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: map[string]memoryEntry{}}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// # Forget expired keys from time to time, so map doesn't grow with every IP seen
+	if now.Sub(s.lastSweep) > memorySweepInterval {
+		for key, entry := range s.entries {
+			if entry.expires.Before(now) {
+				delete(s.entries, key)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	state, result := rule.Take(s.entries[key].state, now)
+	s.entries[key] = memoryEntry{state: state, expires: rule.Expires(state)}
+
+	return result, nil
+}
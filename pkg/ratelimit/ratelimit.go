@@ -0,0 +1,271 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// AnyMethod matches every method
+	AnyMethod = "*"
+
+	// ByUser limits authenticated principal, anonymous requests are limited by IP
+	ByUser = "user"
+	// ByIP limits client IP
+	ByIP = "ip"
+
+	TokenBucket   = "token-bucket"
+	SlidingWindow = "sliding-window"
+)
+
+// Rule is parsed from `<method>:<by>:<algorithm>:<limit>/<period>`,
+// e.g. `SignIn:ip:sliding-window:5/1m` or `*:user:token-bucket:100/1m`
+type Rule struct {
+	// Method is MainApi method name or AnyMethod
+	Method    string
+	By        string
+	Algorithm string
+	Limit     int
+	Period    time.Duration
+}
+
+func (r Rule) String() string {
+	return fmt.Sprintf("%s:%s:%s:%d/%s", r.Method, r.By, r.Algorithm, r.Limit, r.Period)
+}
+
+// Matches reports if rule applies to method
+func (r Rule) Matches(method string) bool {
+	return r.Method == AnyMethod || r.Method == method
+}
+
+// ParseRule parses single rule
+func ParseRule(value string) (Rule, error) {
+	parts := strings.Split(strings.TrimSpace(value), ":")
+	if len(parts) != 4 {
+		return Rule{}, fmt.Errorf("rule %q must be <method>:<by>:<algorithm>:<limit>/<period>", value)
+	}
+
+	rule := Rule{Method: parts[0], By: parts[1], Algorithm: parts[2]}
+
+	if rule.Method == "" {
+		return Rule{}, fmt.Errorf("rule %q: method is required", value)
+	}
+	if rule.By != ByUser && rule.By != ByIP {
+		return Rule{}, fmt.Errorf("rule %q: by must be %s or %s", value, ByUser, ByIP)
+	}
+	if rule.Algorithm != TokenBucket && rule.Algorithm != SlidingWindow {
+		return Rule{}, fmt.Errorf("rule %q: algorithm must be %s or %s", value, TokenBucket, SlidingWindow)
+	}
+
+	limit, period, ok := strings.Cut(parts[3], "/")
+	if !ok {
+		return Rule{}, fmt.Errorf("rule %q: rate must be <limit>/<period>", value)
+	}
+
+	var err error
+	rule.Limit, err = strconv.Atoi(limit)
+	if err != nil || rule.Limit <= 0 {
+		return Rule{}, fmt.Errorf("rule %q: limit must be positive integer", value)
+	}
+
+	rule.Period, err = time.ParseDuration(period)
+	if err != nil || rule.Period <= 0 {
+		return Rule{}, fmt.Errorf("rule %q: period must be positive duration like 1s, 1m or 1h", value)
+	}
+
+	return rule, nil
+}
+
+// ParseRules parses RATE_LIMIT_RULES, empty values are skipped
+func ParseRules(values []string) ([]Rule, error) {
+	rules := []Rule{}
+
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+
+		rule, err := ParseRule(value)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// # Algorithms
+
+// State of the key: tokens and time of last refill for token bucket,
+// count of current and previous windows and current window start for sliding window
+type State struct {
+	Value    float64
+	Previous float64
+	At       time.Time
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is when limit is fully restored (token bucket) or current window ends (sliding window)
+	Reset time.Duration
+	// RetryAfter is set when request is not allowed
+	RetryAfter time.Duration
+}
+
+// Take applies one request to state, zero state is a new key
+func (r Rule) Take(state State, now time.Time) (State, Result) {
+	if r.Algorithm == SlidingWindow {
+		return r.takeSlidingWindow(state, now)
+	}
+
+	return r.takeTokenBucket(state, now)
+}
+
+// Expires is when state of the key can be forgotten
+func (r Rule) Expires(state State) time.Time {
+	return state.At.Add(2 * r.Period)
+}
+
+func (r Rule) takeTokenBucket(state State, now time.Time) (State, Result) {
+	limit := float64(r.Limit)
+	rate := limit / r.Period.Seconds()
+
+	tokens := limit
+	if !state.At.IsZero() {
+		tokens = math.Min(limit, state.Value+now.Sub(state.At).Seconds()*rate)
+	}
+
+	result := Result{Limit: r.Limit}
+	if tokens >= 1 {
+		tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	result.Remaining = int(tokens)
+	result.Reset = seconds((limit - tokens) / rate)
+
+	return State{Value: tokens, At: now}, result
+}
+
+// takeSlidingWindow approximates sliding window by weighting previous fixed window count
+func (r Rule) takeSlidingWindow(state State, now time.Time) (State, Result) {
+	windowStart := now.Truncate(r.Period)
+
+	switch {
+	case state.At.Equal(windowStart):
+	case state.At.Equal(windowStart.Add(-r.Period)):
+		state = State{Previous: state.Value, At: windowStart}
+	default:
+		state = State{At: windowStart}
+	}
+
+	elapsed := now.Sub(windowStart)
+	weight := 1 - elapsed.Seconds()/r.Period.Seconds()
+	estimated := state.Previous*weight + state.Value
+
+	result := Result{Limit: r.Limit, Reset: r.Period - elapsed}
+	if estimated+1 <= float64(r.Limit) {
+		state.Value++
+		estimated++
+		result.Allowed = true
+	} else if state.Value+1 > float64(r.Limit) {
+		// # Current window alone is full
+		result.RetryAfter = r.Period - elapsed
+	} else {
+		// # Wait until weight of previous window drops enough
+		allowedAt := seconds(r.Period.Seconds() * (1 - (float64(r.Limit)-1-state.Value)/state.Previous))
+		result.RetryAfter = max(allowedAt-elapsed, time.Millisecond)
+	}
+
+	result.Remaining = max(0, int(float64(r.Limit)-estimated))
+
+	return state, result
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// # Limiter
+
+// Subject is who makes the request
+type Subject struct {
+	// User is authenticated principal, empty for anonymous requests
+	User string
+	IP   string
+}
+
+// Store keeps state of keys, it must apply Rule.Take atomically
+type Store interface {
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// Limiter applies current rules to calls of method by subject
+type Limiter struct {
+	Store Store
+	// Rules returns current rules, they are reloaded without restart
+	Rules func() []Rule
+	// OnError is called when store fails, request is allowed then
+	OnError func(err error)
+}
+
+// Allow takes request from every rule matching method. Result is the denied one
+// with longest RetryAfter or the one with least remaining; ok is false when no rule matched.
+func (l *Limiter) Allow(ctx context.Context, method string, subject Subject) (result Result, ok bool) {
+	now := time.Now()
+	result = Result{Allowed: true}
+
+	for _, rule := range l.Rules() {
+		if !rule.Matches(method) {
+			continue
+		}
+
+		key := subject.key(rule.By)
+		if key == "" {
+			continue
+		}
+
+		// # Rule is part of the key, so changed rule starts from scratch and `*` rule is shared by methods
+		ruleResult, err := l.Store.Take(ctx, rule.String()+"|"+key, rule, now)
+		if err != nil {
+			if l.OnError != nil {
+				l.OnError(err)
+			}
+			continue
+		}
+
+		switch {
+		case !ok:
+			result = ruleResult
+		case !ruleResult.Allowed && (result.Allowed || ruleResult.RetryAfter > result.RetryAfter):
+			result = ruleResult
+		case ruleResult.Allowed && result.Allowed && ruleResult.Remaining < result.Remaining:
+			result = ruleResult
+		}
+		ok = true
+	}
+
+	return result, ok
+}
+
+func (s Subject) key(by string) string {
+	if by == ByUser && s.User != "" {
+		return "user:" + s.User
+	}
+
+	if s.IP == "" {
+		return ""
+	}
+
+	return "ip:" + s.IP
+}
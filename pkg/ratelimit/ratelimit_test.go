@@ -0,0 +1,202 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Dionid/go-boiler/pkg/ratelimit"
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func mustRule(t *testing.T, value string) ratelimit.Rule {
+	rule, err := ratelimit.ParseRule(value)
+	assert.Nil(t, err)
+	return rule
+}
+
+func TestUnitParseRules(t *testing.T) {
+	rules, err := ratelimit.ParseRules([]string{"SignIn:ip:sliding-window:5/1m", " *:user:token-bucket:100/1h ", ""})
+	assert.Nil(t, err)
+	assert.Equal(t, []ratelimit.Rule{
+		{Method: "SignIn", By: ratelimit.ByIP, Algorithm: ratelimit.SlidingWindow, Limit: 5, Period: time.Minute},
+		{Method: "*", By: ratelimit.ByUser, Algorithm: ratelimit.TokenBucket, Limit: 100, Period: time.Hour},
+	}, rules)
+
+	for _, invalid := range []string{
+		"SignIn:ip:sliding-window",
+		":ip:sliding-window:5/1m",
+		"SignIn:token:sliding-window:5/1m",
+		"SignIn:ip:leaky-bucket:5/1m",
+		"SignIn:ip:sliding-window:5",
+		"SignIn:ip:sliding-window:0/1m",
+		"SignIn:ip:sliding-window:5/minute",
+	} {
+		_, err := ratelimit.ParseRules([]string{invalid})
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestUnitTokenBucket(t *testing.T) {
+	rule := mustRule(t, "*:ip:token-bucket:2/1s")
+	now := time.Now()
+
+	state, result := rule.Take(ratelimit.State{}, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+
+	state, result = rule.Take(state, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, time.Second, result.Reset)
+
+	state, result = rule.Take(state, now)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 500*time.Millisecond, result.RetryAfter)
+
+	// # One token is refilled every 500ms
+	_, result = rule.Take(state, now.Add(500*time.Millisecond))
+	assert.True(t, result.Allowed)
+}
+
+func TestUnitSlidingWindow(t *testing.T) {
+	rule := mustRule(t, "*:ip:sliding-window:2/1m")
+	windowStart := time.Now().Truncate(time.Minute)
+
+	state, result := rule.Take(ratelimit.State{}, windowStart)
+	assert.True(t, result.Allowed)
+	state, result = rule.Take(state, windowStart.Add(10*time.Second))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 0, result.Remaining)
+	assert.Equal(t, 50*time.Second, result.Reset)
+
+	state, result = rule.Take(state, windowStart.Add(20*time.Second))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 40*time.Second, result.RetryAfter)
+
+	// # Previous window weighs 3/4 at 15s of the next one: 2*0.75 + 0 + 1 > 2
+	next := windowStart.Add(time.Minute)
+	state, result = rule.Take(state, next.Add(15*time.Second))
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 15*time.Second, result.RetryAfter)
+
+	_, result = rule.Take(state, next.Add(30*time.Second))
+	assert.True(t, result.Allowed)
+
+	// # Window older than previous one is forgotten
+	_, result = rule.Take(state, next.Add(3*time.Minute))
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 1, result.Remaining)
+}
+
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, rule ratelimit.Rule, now time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store is down")
+}
+
+func TestUnitLimiter(t *testing.T) {
+	rules := []ratelimit.Rule{
+		mustRule(t, "SignIn:ip:token-bucket:1/1h"),
+		mustRule(t, "*:user:token-bucket:2/1h"),
+	}
+	limiter := &ratelimit.Limiter{
+		Store: ratelimit.NewMemoryStore(),
+		Rules: func() []ratelimit.Rule { return rules },
+	}
+	ctx := context.Background()
+
+	t.Run("most restrictive rule wins", func(t *testing.T) {
+		result, ok := limiter.Allow(ctx, "SignIn", ratelimit.Subject{User: "1", IP: "10.0.0.1"})
+		assert.True(t, ok)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, 1, result.Limit)
+
+		result, _ = limiter.Allow(ctx, "SignIn", ratelimit.Subject{User: "1", IP: "10.0.0.1"})
+		assert.False(t, result.Allowed)
+
+		// # Other IP, same user: `*` rule is shared by methods
+		result, _ = limiter.Allow(ctx, "SignUp", ratelimit.Subject{User: "1", IP: "10.0.0.2"})
+		assert.False(t, result.Allowed)
+		assert.Equal(t, 2, result.Limit)
+	})
+
+	t.Run("anonymous user is limited by ip", func(t *testing.T) {
+		for range 2 {
+			result, _ := limiter.Allow(ctx, "SignUp", ratelimit.Subject{IP: "10.0.0.3"})
+			assert.True(t, result.Allowed)
+		}
+		result, _ := limiter.Allow(ctx, "SignUp", ratelimit.Subject{IP: "10.0.0.3"})
+		assert.False(t, result.Allowed)
+	})
+
+	t.Run("no matching rules", func(t *testing.T) {
+		_, ok := (&ratelimit.Limiter{
+			Store: ratelimit.NewMemoryStore(),
+			Rules: func() []ratelimit.Rule { return rules[:1] },
+		}).Allow(ctx, "SignUp", ratelimit.Subject{IP: "10.0.0.1"})
+		assert.False(t, ok)
+	})
+
+	t.Run("store errors allow request", func(t *testing.T) {
+		errs := 0
+		_, ok := (&ratelimit.Limiter{
+			Store:   failingStore{},
+			Rules:   func() []ratelimit.Rule { return rules },
+			OnError: func(err error) { errs++ },
+		}).Allow(ctx, "SignIn", ratelimit.Subject{IP: "10.0.0.1"})
+		assert.False(t, ok)
+		assert.Equal(t, 2, errs)
+	})
+}
+
+func TestUnitInterceptors(t *testing.T) {
+	limiter := &ratelimit.Limiter{
+		Store: ratelimit.NewMemoryStore(),
+		Rules: func() []ratelimit.Rule { return []ratelimit.Rule{mustRule(t, "*:ip:token-bucket:1/1h")} },
+	}
+
+	t.Run("unary", func(t *testing.T) {
+		interceptor := ratelimit.UnaryServerInterceptor(limiter, func(ctx context.Context, req any) ratelimit.Subject {
+			return ratelimit.Subject{IP: "unary"}
+		})
+		info := &grpc.UnaryServerInfo{FullMethod: "/go_boiler.calls.MainApi/SignIn"}
+		handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+		resp, err := interceptor(context.Background(), nil, info, handler)
+		assert.Nil(t, err)
+		assert.Equal(t, "ok", resp)
+
+		_, err = interceptor(context.Background(), nil, info, handler)
+		assert.Equal(t, http.StatusTooManyRequests, err.(terrors.Error).GetCode())
+		assert.Equal(t, "rate_limit.exceeded", err.(terrors.PublicError).GetReason())
+	})
+
+	t.Run("echo", func(t *testing.T) {
+		e := echo.New()
+		e.HTTPErrorHandler = func(err error, c echo.Context) {
+			c.NoContent(err.(terrors.Error).GetCode())
+		}
+		e.GET("/ws", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, ratelimit.EchoMiddleware(limiter, "WebSocket", func(c echo.Context) ratelimit.Subject {
+			return ratelimit.Subject{IP: c.RealIP()}
+		}))
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "3600", rec.Header().Get("RateLimit-Reset"))
+
+		rec = httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "3600", rec.Header().Get("Retry-After"))
+	})
+}
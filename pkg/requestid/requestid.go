@@ -0,0 +1,119 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	Header      = echo.HeaderXRequestID
+	MetadataKey = "x-request-id"
+
+	maxLength = 128
+)
+
+type ctxKey struct{}
+
+// WithRequestId stores request id in ctx
+func WithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, requestId)
+}
+
+// FromContext returns id of the current request, empty if there is none
+func FromContext(ctx context.Context) string {
+	requestId, _ := ctx.Value(ctxKey{}).(string)
+	return requestId
+}
+
+// ZapFields returns request id field
+func ZapFields(ctx context.Context) []zap.Field {
+	requestId := FromContext(ctx)
+	if requestId == "" {
+		return nil
+	}
+
+	return []zap.Field{zap.String("requestId", requestId)}
+}
+
+// accept returns incoming id if it is acceptable or generates new one
+func accept(incoming string) string {
+	if incoming == "" || len(incoming) > maxLength {
+		return uuid.NewString()
+	}
+
+	return incoming
+}
+
+// EchoMiddleware accepts `X-Request-Id` or generates new one,
+// stores it in request context and request header (so gateway forwards it to gRPC)
+// and echoes it back in response
+func EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			requestId := accept(request.Header.Get(Header))
+
+			request.Header.Set(Header, requestId)
+			c.SetRequest(request.WithContext(WithRequestId(request.Context(), requestId)))
+
+			// # Gateway can already set it from gRPC header
+			c.Response().Before(func() {
+				if c.Response().Header().Get(Header) == "" {
+					c.Response().Header().Set(Header, requestId)
+				}
+			})
+
+			return next(c)
+		}
+	}
+}
+
+func fromIncomingContext(ctx context.Context) context.Context {
+	incoming := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			incoming = values[0]
+		}
+	}
+
+	return WithRequestId(ctx, accept(incoming))
+}
+
+// UnaryServerInterceptor accepts `x-request-id` metadata or generates new one,
+// stores it in context and sends it back in header
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = fromIncomingContext(ctx)
+
+		// # Best effort: fails only when there is no transport stream (direct calls)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, FromContext(ctx)))
+
+		return handler(ctx, req)
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// StreamServerInterceptor accepts `x-request-id` metadata or generates new one,
+// stores it in context and sends it back in header
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := fromIncomingContext(ss.Context())
+
+		_ = ss.SetHeader(metadata.Pairs(MetadataKey, FromContext(ctx)))
+
+		return handler(srv, &serverStream{ss, ctx})
+	}
+}
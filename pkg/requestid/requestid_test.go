@@ -0,0 +1,64 @@
+package requestid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/requestid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnitRequestId(t *testing.T) {
+	t.Run("echo accepts incoming", func(t *testing.T) {
+		e := echo.New()
+		e.Use(requestid.EchoMiddleware())
+		e.GET("/", func(c echo.Context) error {
+			return c.String(http.StatusOK, requestid.FromContext(c.Request().Context()))
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(requestid.Header, "abc")
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		assert.Equal(t, "abc", recorder.Body.String())
+		assert.Equal(t, "abc", recorder.Header().Get(requestid.Header))
+	})
+
+	t.Run("echo generates", func(t *testing.T) {
+		e := echo.New()
+		e.Use(requestid.EchoMiddleware())
+		e.GET("/", func(c echo.Context) error {
+			return c.String(http.StatusOK, requestid.FromContext(c.Request().Context()))
+		})
+
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(requestid.Header, strings.Repeat("a", 129))
+		recorder := httptest.NewRecorder()
+		e.ServeHTTP(recorder, request)
+
+		assert.Len(t, recorder.Body.String(), 36)
+		assert.Equal(t, recorder.Body.String(), recorder.Header().Get(requestid.Header))
+	})
+
+	t.Run("grpc metadata", func(t *testing.T) {
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestid.MetadataKey, "abc"))
+		interceptor := requestid.UnaryServerInterceptor()
+
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			assert.Equal(t, "abc", requestid.FromContext(ctx))
+			assert.Len(t, requestid.ZapFields(ctx), 1)
+			return nil, nil
+		})
+		assert.Nil(t, err)
+	})
+
+	assert.Empty(t, requestid.FromContext(context.Background()))
+	assert.Nil(t, requestid.ZapFields(context.Background()))
+}
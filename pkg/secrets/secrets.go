@@ -0,0 +1,222 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	SchemeFile  = "file"
+	SchemeEnv   = "env"
+	SchemeVault = "vault"
+)
+
+// Ref is a reference to secret:
+//
+//	file:///run/secrets/jwt  – file content
+//	env://JWT_SECRET         – environment variable
+//	vault://secret/app#jwt   – key "jwt" of KV secret "app" in "secret" mount
+type Ref struct {
+	Scheme string
+	Path   string
+	Key    string
+}
+
+func (r Ref) String() string {
+	s := fmt.Sprintf("%s://%s", r.Scheme, r.Path)
+	if r.Key != "" {
+		s += "#" + r.Key
+	}
+	return s
+}
+
+// ParseRef parses value as a reference, ok is false for plain values
+func ParseRef(value string) (ref Ref, ok bool) {
+	scheme, rest, found := strings.Cut(value, "://")
+	if !found {
+		return Ref{}, false
+	}
+
+	switch scheme {
+	case SchemeFile, SchemeEnv, SchemeVault:
+	default:
+		return Ref{}, false
+	}
+
+	path, key, _ := strings.Cut(rest, "#")
+
+	return Ref{Scheme: scheme, Path: path, Key: key}, true
+}
+
+// SecretProvider returns current value of the secret
+type SecretProvider interface {
+	Get(ctx context.Context, ref Ref) (string, error)
+}
+
+// # Providers
+
+// FileProvider reads file, trailing newline is trimmed
+type FileProvider struct{}
+
+func (FileProvider) Get(ctx context.Context, ref Ref) (string, error) {
+	path, err := url.PathUnescape(ref.Path)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// EnvProvider reads environment variable
+type EnvProvider struct{}
+
+func (EnvProvider) Get(ctx context.Context, ref Ref) (string, error) {
+	value, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return "", fmt.Errorf("env %s is not set", ref.Path)
+	}
+
+	return value, nil
+}
+
+// # Resolver
+
+// Secret is resolved value, refreshed by Resolver
+type Secret struct {
+	ref   *Ref
+	value atomic.Pointer[string]
+}
+
+// Static returns secret that never changes
+func Static(value string) *Secret {
+	s := &Secret{}
+	s.value.Store(&value)
+	return s
+}
+
+func (s *Secret) Get() string {
+	return *s.value.Load()
+}
+
+// Bytes is handy for JWT keys
+func (s *Secret) Bytes() []byte {
+	return []byte(s.Get())
+}
+
+// Resolver resolves references by scheme and refreshes resolved secrets
+type Resolver struct {
+	providers map[string]SecretProvider
+
+	mu      sync.Mutex
+	secrets []*Secret
+}
+
+// NewResolver creates resolver with file and env providers
+func NewResolver() *Resolver {
+	return &Resolver{
+		providers: map[string]SecretProvider{
+			SchemeFile: FileProvider{},
+			SchemeEnv:  EnvProvider{},
+		},
+	}
+}
+
+// Register sets provider of the scheme
+func (r *Resolver) Register(scheme string, provider SecretProvider) {
+	r.providers[scheme] = provider
+}
+
+func (r *Resolver) get(ctx context.Context, ref Ref) (string, error) {
+	provider, ok := r.providers[ref.Scheme]
+	if !ok {
+		return "", fmt.Errorf("%s: no provider for %s scheme", ref, ref.Scheme)
+	}
+
+	value, err := provider.Get(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	return value, nil
+}
+
+// Resolve returns value of the reference once, plain values are returned as is
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return value, nil
+	}
+
+	return r.get(ctx, ref)
+}
+
+// Secret resolves value and keeps it up to date on Refresh
+func (r *Resolver) Secret(ctx context.Context, value string) (*Secret, error) {
+	ref, ok := ParseRef(value)
+	if !ok {
+		return Static(value), nil
+	}
+
+	resolved, err := r.get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Secret{ref: &ref}
+	s.value.Store(&resolved)
+
+	r.mu.Lock()
+	r.secrets = append(r.secrets, s)
+	r.mu.Unlock()
+
+	return s, nil
+}
+
+// Refresh re-resolves secrets, failed ones keep previous value
+func (r *Resolver) Refresh(ctx context.Context) error {
+	r.mu.Lock()
+	secrets := append([]*Secret{}, r.secrets...)
+	r.mu.Unlock()
+
+	var errs []error
+	for _, s := range secrets {
+		value, err := r.get(ctx, *s.ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		s.value.Store(&value)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Run refreshes secrets every interval until ctx is done
+func (r *Resolver) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
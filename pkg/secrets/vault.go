@@ -0,0 +1,81 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads HashiCorp Vault KV v2 secrets.
+// vault://secret/app#jwt reads key "jwt" from GET {Address}/v1/secret/data/app.
+type VaultProvider struct {
+	Address string
+	Token   string
+	Client  *http.Client
+}
+
+func NewVaultProvider(address string, token string) *VaultProvider {
+	return &VaultProvider{
+		Address: strings.TrimRight(address, "/"),
+		Token:   token,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type vaultKvResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+	Errors []string `json:"errors"`
+}
+
+func (p *VaultProvider) Get(ctx context.Context, ref Ref) (string, error) {
+	mount, path, found := strings.Cut(strings.Trim(ref.Path, "/"), "/")
+	if !found || path == "" {
+		return "", fmt.Errorf("path must be mount/secret")
+	}
+	if ref.Key == "" {
+		return "", fmt.Errorf("key is required")
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/v1/%s/data/%s", p.Address, mount, path), nil)
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("X-Vault-Token", p.Token)
+
+	response, err := p.Client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", err
+	}
+
+	result := vaultKvResponse{}
+	if err := json.Unmarshal(body, &result); err != nil && response.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("vault response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded %d: %s", response.StatusCode, strings.Join(result.Errors, "; "))
+	}
+
+	value, ok := result.Data.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found", ref.Key)
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+
+	return fmt.Sprint(value), nil
+}
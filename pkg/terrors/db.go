@@ -1,15 +1,70 @@
 package terrors
 
-import "strings"
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/lib/pq"
+)
+
+// # SQLSTATE codes
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	PgForeignKeyViolation  = "23503"
+	PgUniqueViolation      = "23505"
+	PgSerializationFailure = "40001"
+	PgDeadlockDetected     = "40P01"
+)
 
 func IsNotFoundErr(err error) bool {
-	return strings.Contains(err.Error(), "no rows")
+	if errors.Is(err, sql.ErrNoRows) {
+		return true
+	}
+
+	var tErr Error
+	if errors.As(err, &tErr) {
+		return tErr.GetCode() == http.StatusNotFound
+	}
+
+	return false
+}
+
+// PgErrorCode returns SQLSTATE code of the first pq.Error in chain
+func PgErrorCode(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code)
+	}
+
+	return ""
+}
+
+// IsRetryable reports whether operation failed with Postgres error
+// that can disappear on retry (serialization failure, deadlock).
+// Other 503 errors aren't retryable, they may come from side effects of operation.
+func IsRetryable(err error) bool {
+	switch PgErrorCode(err) {
+	case PgSerializationFailure, PgDeadlockDetected:
+		return true
+	}
+
+	return false
 }
 
 func NewDbErr(err error) Error {
-	if IsNotFoundErr(err) {
-		return NewNotFoundError("not found: ", nil)
+	if errors.Is(err, sql.ErrNoRows) {
+		return NewNotFoundError("not found", nil).WithReason(ReasonNotFound, nil).WithCause(err)
+	}
+
+	switch PgErrorCode(err) {
+	case PgUniqueViolation:
+		return NewConflictError("already exists", nil).WithReason(ReasonAlreadyExists, nil).WithCause(err)
+	case PgForeignKeyViolation:
+		return NewConflictError("conflicts with related entity", nil).WithReason(ReasonRelatedConflict, nil).WithCause(err)
+	case PgSerializationFailure, PgDeadlockDetected:
+		return NewRetryableError(err.Error()).WithCause(err)
 	}
 
-	return NewPrivateError(err.Error())
+	return WrapPrivateError(err, err.Error())
 }
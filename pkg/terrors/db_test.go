@@ -0,0 +1,62 @@
+package terrors_test
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitDbErr(t *testing.T) {
+	t.Run("no rows", func(t *testing.T) {
+		cause := fmt.Errorf("select user: %w", sql.ErrNoRows)
+		err := terrors.NewDbErr(cause)
+
+		assert.Equal(t, http.StatusNotFound, err.GetCode())
+		assert.True(t, errors.Is(err, sql.ErrNoRows))
+		assert.True(t, terrors.IsNotFoundErr(err))
+	})
+
+	t.Run("unique violation", func(t *testing.T) {
+		err := terrors.NewDbErr(&pq.Error{Code: terrors.PgUniqueViolation})
+
+		assert.Equal(t, http.StatusConflict, err.GetCode())
+
+		var pqErr *pq.Error
+		assert.True(t, errors.As(err, &pqErr))
+	})
+
+	t.Run("serialization failure", func(t *testing.T) {
+		err := terrors.NewDbErr(&pq.Error{Code: terrors.PgSerializationFailure})
+
+		assert.True(t, terrors.IsRetryable(err))
+		assert.False(t, terrors.IsRetryable(terrors.NewDbErr(errors.New("connection refused"))))
+		assert.False(t, terrors.IsRetryable(terrors.NewRetryableError("unavailable")))
+		assert.True(t, terrors.IsRetryable(fmt.Errorf("in feature: %w", err)))
+	})
+}
+
+func TestUnitErrorChain(t *testing.T) {
+	terrors.SetStackCapture(true)
+	t.Cleanup(func() { terrors.SetStackCapture(false) })
+
+	root := errors.New("connection refused")
+	err := terrors.WrapPrivateError(root, "Failed to query user")
+
+	assert.True(t, errors.Is(err, root))
+	assert.Equal(t, []string{"Failed to query user", "connection refused"}, terrors.ErrorChain(err))
+	assert.Contains(t, err.StackTrace(), "TestUnitErrorChain")
+
+	fields := terrors.ZapFields(err)
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		keys = append(keys, field.Key)
+	}
+	assert.Contains(t, keys, "errorChain")
+	assert.Contains(t, keys, "errorStack")
+}
@@ -0,0 +1,249 @@
+package terrors
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	// GRPCErrorDomain is set as ErrorInfo.Domain on every status built from terrors
+	GRPCErrorDomain = "terrors"
+
+	grpcReasonPublic  = "PUBLIC_ERROR"
+	grpcReasonPrivate = "PRIVATE_ERROR"
+
+	grpcMetadataHttpCode = "http_code"
+	grpcMetadataReason   = "reason"
+)
+
+// GRPCCode maps HTTP status code (which terrors use as error code) to gRPC code
+func GRPCCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusOK:
+		return codes.OK
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case http.StatusInternalServerError:
+		return codes.Internal
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+
+	if httpCode >= http.StatusBadRequest && httpCode < http.StatusInternalServerError {
+		return codes.FailedPrecondition
+	}
+
+	return codes.Unknown
+}
+
+// HTTPCode maps gRPC code to HTTP status code
+func HTTPCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.FailedPrecondition:
+		return http.StatusPreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+
+	return http.StatusInternalServerError
+}
+
+// ToGRPCStatus converts terrors.Error to gRPC status.
+// Private message never leaves the server, only public message,
+// code and data (if it can be represented as protobuf) are sent.
+func ToGRPCStatus(err Error) *status.Status {
+	reason := grpcReasonPublic
+	if _, ok := err.(*PrivateError); ok {
+		reason = grpcReasonPrivate
+	}
+
+	st := status.New(GRPCCode(err.GetCode()), err.GetPublicMessage())
+
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: GRPCErrorDomain,
+			Metadata: map[string]string{
+				grpcMetadataHttpCode: strconv.Itoa(err.GetCode()),
+				grpcMetadataReason:   grpcReason(err),
+			},
+		},
+	}
+
+	if data := grpcDataDetail(err.GetData()); data != nil {
+		details = append(details, protoadapt.MessageV1Of(data))
+	}
+
+	withDetails, detailsErr := st.WithDetails(details...)
+	if detailsErr != nil {
+		return st
+	}
+
+	return withDetails
+}
+
+func grpcReason(err Error) string {
+	if v, ok := err.(reasoned); ok {
+		return v.GetReason()
+	}
+
+	return ""
+}
+
+func grpcDataDetail(data any) proto.Message {
+	if data == nil {
+		return nil
+	}
+
+	if msg, ok := data.(proto.Message); ok {
+		return msg
+	}
+
+	value, err := structpb.NewValue(data)
+	if err != nil {
+		return nil
+	}
+
+	return value
+}
+
+// FromGRPCStatus reconstructs terrors.Error from gRPC status
+func FromGRPCStatus(st *status.Status) Error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	code := HTTPCode(st.Code())
+	isPrivate := code >= http.StatusInternalServerError
+
+	var data any
+	var reason string
+
+	for _, detail := range st.Details() {
+		switch v := detail.(type) {
+		case *errdetails.ErrorInfo:
+			if v.GetDomain() != GRPCErrorDomain {
+				continue
+			}
+
+			isPrivate = v.GetReason() == grpcReasonPrivate
+
+			if httpCode, err := strconv.Atoi(v.GetMetadata()[grpcMetadataHttpCode]); err == nil {
+				code = httpCode
+			}
+
+			reason = v.GetMetadata()[grpcMetadataReason]
+		case *structpb.Value:
+			data = v.AsInterface()
+		case proto.Message:
+			data = v
+		}
+	}
+
+	base := BaseErrorSt{
+		Code:           code,
+		PublicMessage:  st.Message(),
+		PrivateMessage: st.Message(),
+		Data:           data,
+		Cause:          st.Err(),
+		Reason:         reason,
+	}
+
+	if isPrivate {
+		return &PrivateError{BaseErrorSt: base}
+	}
+
+	return PublicError{base}
+}
+
+// FromGRPCError reconstructs terrors.Error from error returned by gRPC client
+func FromGRPCError(err error) (Error, bool) {
+	if err == nil {
+		return nil, false
+	}
+
+	if tErr, ok := err.(Error); ok {
+		return tErr, true
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil, false
+	}
+
+	return FromGRPCStatus(st), true
+}
+
+func toGRPCError(err error) error {
+	if tErr, ok := err.(Error); ok {
+		return ToGRPCStatus(tErr).Err()
+	}
+
+	return err
+}
+
+// UnaryServerInterceptor converts terrors.Error returned by handlers to gRPC status
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, toGRPCError(err)
+		}
+
+		return resp, nil
+	}
+}
+
+// StreamServerInterceptor converts terrors.Error returned by handlers to gRPC status
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return toGRPCError(handler(srv, ss))
+	}
+}
@@ -0,0 +1,67 @@
+package terrors_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/terrors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitGRPCStatus(t *testing.T) {
+	t.Run("public error", func(t *testing.T) {
+		st := terrors.ToGRPCStatus(terrors.NewNotFoundError("user not found", map[string]any{"field": "email"}))
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "user not found", st.Message())
+
+		tErr := terrors.FromGRPCStatus(st)
+		publicErr, ok := tErr.(terrors.PublicError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusNotFound, publicErr.GetCode())
+		assert.Equal(t, "user not found", publicErr.GetPublicMessage())
+		assert.Equal(t, map[string]any{"field": "email"}, publicErr.GetData())
+	})
+
+	t.Run("private error", func(t *testing.T) {
+		st := terrors.ToGRPCStatus(terrors.NewPrivateError("connection refused"))
+		assert.Equal(t, codes.Internal, st.Code())
+		assert.NotContains(t, st.Message(), "connection refused")
+
+		tErr := terrors.FromGRPCStatus(st)
+		_, ok := tErr.(*terrors.PrivateError)
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusInternalServerError, tErr.GetCode())
+	})
+
+	t.Run("too many requests", func(t *testing.T) {
+		st := terrors.ToGRPCStatus(terrors.NewTooManyRequestsError("too many requests", nil))
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+		tErr, ok := terrors.FromGRPCError(status.Error(codes.ResourceExhausted, "quota"))
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusTooManyRequests, tErr.GetCode())
+	})
+
+	t.Run("plain status", func(t *testing.T) {
+		tErr, ok := terrors.FromGRPCError(status.Error(codes.Unauthenticated, "no token"))
+		assert.True(t, ok)
+		assert.Equal(t, http.StatusUnauthorized, tErr.GetCode())
+		assert.Equal(t, "no token", tErr.GetPublicMessage())
+
+		_, ok = terrors.FromGRPCError(errors.New("not a status"))
+		assert.False(t, ok)
+	})
+
+	t.Run("interceptor", func(t *testing.T) {
+		interceptor := terrors.UnaryServerInterceptor()
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			return nil, terrors.NewValidationError("email is required", nil)
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -0,0 +1,44 @@
+package terrors
+
+// # Reasons used by terrors itself
+const (
+	ReasonInternal             = "internal"
+	ReasonTemporaryUnavailable = "temporary_unavailable"
+	ReasonNotFound             = "not_found"
+	ReasonAlreadyExists        = "already_exists"
+	ReasonRelatedConflict      = "related_conflict"
+)
+
+// Translator returns localized message for reason, false if there is no such message
+type Translator func(reason string, params map[string]any) (string, bool)
+
+type reasoned interface {
+	GetReason() string
+	GetParams() map[string]any
+}
+
+// Localize returns copy of err with public message translated by its reason.
+// Errors without reason or translation are returned as is.
+func Localize(err Error, translate Translator) Error {
+	v, ok := err.(reasoned)
+	if !ok || v.GetReason() == "" {
+		return err
+	}
+
+	message, ok := translate(v.GetReason(), v.GetParams())
+	if !ok {
+		return err
+	}
+
+	switch tErr := err.(type) {
+	case PublicError:
+		tErr.PublicMessage = message
+		return tErr
+	case *PrivateError:
+		localized := *tErr
+		localized.PublicMessage = message
+		return &localized
+	}
+
+	return err
+}
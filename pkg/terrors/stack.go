@@ -0,0 +1,48 @@
+package terrors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync/atomic"
+)
+
+const maxStackDepth = 32
+
+var stackCaptureEnabled atomic.Bool
+
+// SetStackCapture enables or disables stack capture for PrivateError
+func SetStackCapture(enabled bool) {
+	stackCaptureEnabled.Store(enabled)
+}
+
+func captureStack() []uintptr {
+	if !stackCaptureEnabled.Load() {
+		return nil
+	}
+
+	pcs := make([]uintptr, maxStackDepth)
+	// # Skip runtime.Callers, captureStack and constructor
+	n := runtime.Callers(3, pcs)
+
+	return pcs[:n]
+}
+
+func formatStack(stack []uintptr) string {
+	if len(stack) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	frames := runtime.CallersFrames(stack)
+
+	for {
+		frame, more := frames.Next()
+		builder.WriteString(fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line))
+		if !more {
+			break
+		}
+	}
+
+	return builder.String()
+}
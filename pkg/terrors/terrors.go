@@ -19,6 +19,11 @@ type BaseErrorSt struct {
 	PublicMessage  string
 	PrivateMessage string
 	Data           any
+	Cause          error
+	// Reason is a stable key of the error used to find localized public message
+	Reason string
+	// Params are interpolated into localized public message
+	Params map[string]any
 }
 
 func (e BaseErrorSt) IsTError() {}
@@ -43,32 +48,96 @@ func (e BaseErrorSt) GetData() any {
 	return e.Data
 }
 
+func (e BaseErrorSt) GetReason() string {
+	return e.Reason
+}
+
+func (e BaseErrorSt) GetParams() map[string]any {
+	return e.Params
+}
+
+// Unwrap returns cause, so errors.Is / errors.As can traverse the chain
+func (e BaseErrorSt) Unwrap() error {
+	return e.Cause
+}
+
 type PrivateError struct {
 	BaseErrorSt
+	Stack []uintptr
 }
 
 func NewPrivateError(privateMessage string) *PrivateError {
 	return &PrivateError{
-		BaseErrorSt{
-			http.StatusInternalServerError,
-			"Internal error",
-			privateMessage,
-			nil,
+		BaseErrorSt: BaseErrorSt{
+			Code:           http.StatusInternalServerError,
+			PublicMessage:  "Internal error",
+			PrivateMessage: privateMessage,
+			Reason:         ReasonInternal,
 		},
+		Stack: captureStack(),
 	}
 }
 
+// WrapPrivateError creates PrivateError with cause
+func WrapPrivateError(cause error, privateMessage string) *PrivateError {
+	err := NewPrivateError(privateMessage)
+	err.Cause = cause
+	return err
+}
+
+// WithCause sets cause of the error
+func (e *PrivateError) WithCause(cause error) *PrivateError {
+	e.Cause = cause
+	return e
+}
+
+// WithReason sets reason and params used for localization
+func (e *PrivateError) WithReason(reason string, params map[string]any) *PrivateError {
+	e.Reason = reason
+	e.Params = params
+	return e
+}
+
+// StackTrace returns formatted stack captured on creation
+// (empty if stack capture is disabled)
+func (e *PrivateError) StackTrace() string {
+	return formatStack(e.Stack)
+}
+
+// NewRetryableError creates PrivateError signaling that operation
+// can be retried (e.g. serialization failure)
+func NewRetryableError(privateMessage string) *PrivateError {
+	err := NewPrivateError(privateMessage)
+	err.Code = http.StatusServiceUnavailable
+	err.PublicMessage = "Temporary error, try again later"
+	err.Reason = ReasonTemporaryUnavailable
+	return err
+}
+
 type PublicError struct {
 	BaseErrorSt
 }
 
+// WithCause returns copy of the error with cause set
+func (e PublicError) WithCause(cause error) PublicError {
+	e.Cause = cause
+	return e
+}
+
+// WithReason returns copy of the error with reason and params used for localization
+func (e PublicError) WithReason(reason string, params map[string]any) PublicError {
+	e.Reason = reason
+	e.Params = params
+	return e
+}
+
 func NewPublicError(code int, publicMessage string, privateMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			code,
-			publicMessage,
-			privateMessage,
-			data,
+			Code:           code,
+			PublicMessage:  publicMessage,
+			PrivateMessage: privateMessage,
+			Data:           data,
 		},
 	}
 }
@@ -76,10 +145,10 @@ func NewPublicError(code int, publicMessage string, privateMessage string, data
 func NewValidationError(publicMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			http.StatusBadRequest,
-			publicMessage,
-			publicMessage,
-			data,
+			Code:           http.StatusBadRequest,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
 		},
 	}
 }
@@ -87,10 +156,10 @@ func NewValidationError(publicMessage string, data any) PublicError {
 func NewForbiddenError(publicMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			http.StatusForbidden,
-			publicMessage,
-			publicMessage,
-			data,
+			Code:           http.StatusForbidden,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
 		},
 	}
 }
@@ -98,10 +167,10 @@ func NewForbiddenError(publicMessage string, data any) PublicError {
 func NewUnauthorizedError(publicMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			http.StatusUnauthorized,
-			publicMessage,
-			publicMessage,
-			data,
+			Code:           http.StatusUnauthorized,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
 		},
 	}
 }
@@ -109,10 +178,21 @@ func NewUnauthorizedError(publicMessage string, data any) PublicError {
 func NewNotFoundError(publicMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			http.StatusNotFound,
-			publicMessage,
-			publicMessage,
-			data,
+			Code:           http.StatusNotFound,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
+		},
+	}
+}
+
+func NewConflictError(publicMessage string, data any) PublicError {
+	return PublicError{
+		BaseErrorSt{
+			Code:           http.StatusConflict,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
 		},
 	}
 }
@@ -124,10 +204,21 @@ func IgnoreError[V any](value V, err error) V {
 func NewTimeoutError(publicMessage string, data any) PublicError {
 	return PublicError{
 		BaseErrorSt{
-			http.StatusNotFound,
-			publicMessage,
-			publicMessage,
-			data,
+			Code:           http.StatusNotFound,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
+		},
+	}
+}
+
+func NewTooManyRequestsError(publicMessage string, data any) PublicError {
+	return PublicError{
+		BaseErrorSt{
+			Code:           http.StatusTooManyRequests,
+			PublicMessage:  publicMessage,
+			PrivateMessage: publicMessage,
+			Data:           data,
 		},
 	}
 }
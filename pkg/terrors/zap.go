@@ -0,0 +1,53 @@
+package terrors
+
+import (
+	"errors"
+
+	"go.uber.org/zap"
+)
+
+// ErrorChain returns messages of err and every error it wraps
+func ErrorChain(err error) []string {
+	chain := []string{}
+
+	for err != nil {
+		if tErr, ok := err.(Error); ok {
+			chain = append(chain, tErr.GetPrivateMessage())
+		} else {
+			chain = append(chain, err.Error())
+		}
+
+		err = errors.Unwrap(err)
+	}
+
+	return chain
+}
+
+// ZapFields describes err with its code, messages, cause chain and stack
+func ZapFields(err error) []zap.Field {
+	if err == nil {
+		return nil
+	}
+
+	fields := []zap.Field{
+		zap.Strings("errorChain", ErrorChain(err)),
+	}
+
+	var tErr Error
+	if errors.As(err, &tErr) {
+		fields = append(
+			fields,
+			zap.Int("errorCode", tErr.GetCode()),
+			zap.String("errorPublicMessage", tErr.GetPublicMessage()),
+		)
+	}
+
+	var privateErr *PrivateError
+	if errors.As(err, &privateErr) {
+		if stack := privateErr.StackTrace(); stack != "" {
+			fields = append(fields, zap.String("errorStack", stack))
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,28 @@
+package tracing
+
+import (
+	"github.com/XSAM/otelsql"
+	"github.com/jmoiron/sqlx"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// OpenPostgres opens postgres pool through driver instrumented by OpenTelemetry,
+// so every query (including generated `maindb` ones) gets its own span
+func OpenPostgres(dataSourceName string) (*sqlx.DB, error) {
+	db, err := otelsql.Open(
+		"postgres",
+		dataSourceName,
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			DisableErrSkip:       true,
+			OmitConnResetSession: true,
+			OmitConnectorConnect: true,
+			OmitRows:             true,
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return sqlx.NewDb(db, "postgres"), nil
+}
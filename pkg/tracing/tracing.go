@@ -0,0 +1,154 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOtlp   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+type Config struct {
+	ServiceName string
+	// Exporter is one of: none, otlp, stdout, file
+	Exporter string
+	// OtlpEndpoint is host:port of OTLP gRPC collector
+	OtlpEndpoint string
+	OtlpInsecure bool
+	// FilePath is used by file exporter
+	FilePath string
+	// SampleRatio of root spans, 1 samples everything
+	SampleRatio float64
+}
+
+func newExporter(ctx context.Context, config Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch config.Exporter {
+	case ExporterOtlp:
+		opts := []otlptracegrpc.Option{}
+		if config.OtlpEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.OtlpEndpoint))
+		}
+		if config.OtlpInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exporter, nil, err
+	case ExporterFile:
+		file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+
+		return exporter, file, nil
+	}
+
+	return nil, nil, fmt.Errorf("unknown tracing exporter: %s", config.Exporter)
+}
+
+// Init sets global tracer provider and W3C trace context propagator.
+// Returned shutdown flushes remaining spans.
+// With "none" (or empty) exporter spans are still created and propagated, but not exported.
+func Init(ctx context.Context, config Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(config.ServiceName),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	}
+
+	var closer io.Closer
+
+	if config.Exporter != "" && config.Exporter != ExporterNone {
+		exporter, exporterCloser, err := newExporter(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+
+		closer = exporterCloser
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			closer.Close()
+		}
+		return err
+	}, nil
+}
+
+// TraceId returns trace id of the span in ctx, empty if there is no valid span
+func TraceId(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+
+	return spanContext.TraceID().String()
+}
+
+// ZapFields returns trace and span ids of the span in ctx
+func ZapFields(ctx context.Context) []zap.Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return nil
+	}
+
+	return []zap.Field{
+		zap.String("traceId", spanContext.TraceID().String()),
+		zap.String("spanId", spanContext.SpanID().String()),
+	}
+}
+
+// Logger returns logger with trace and span ids of the span in ctx
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	fields := ZapFields(ctx)
+	if len(fields) == 0 {
+		return logger
+	}
+
+	return logger.With(fields...)
+}
@@ -0,0 +1,46 @@
+package tracing_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dionid/go-boiler/pkg/tracing"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+)
+
+func TestUnitTracing(t *testing.T) {
+	ctx := context.Background()
+	filePath := filepath.Join(t.TempDir(), "traces.json")
+
+	shutdown, err := tracing.Init(ctx, tracing.Config{
+		ServiceName: "test",
+		Exporter:    tracing.ExporterFile,
+		FilePath:    filePath,
+		SampleRatio: 1,
+	})
+	assert.Nil(t, err)
+
+	spanCtx, span := otel.Tracer("test").Start(ctx, "sign-in")
+
+	traceId := tracing.TraceId(spanCtx)
+	assert.Len(t, traceId, 32)
+
+	fields := tracing.ZapFields(spanCtx)
+	assert.Len(t, fields, 2)
+	assert.Equal(t, traceId, fields[0].String)
+
+	span.End()
+	assert.Nil(t, shutdown(ctx))
+
+	data, err := os.ReadFile(filePath)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(string(data), traceId))
+	assert.True(t, strings.Contains(string(data), "sign-in"))
+
+	assert.Empty(t, tracing.TraceId(ctx))
+	assert.Nil(t, tracing.ZapFields(ctx))
+}
@@ -24,7 +24,8 @@ func main() {
 		fmt.Sprintf("--env-file=%s/%s", pwd, envFile),
 		// fmt.Sprintf("-v%s:/export", exportTo),
 		"--rm",
-		"postgres",
+		// Same as docker-compose, scripts/schema-drift requires pg_dump of this major version
+		"postgres:12.9",
 		"pg_dump",
 		"--schema-only",
 		"--schema=public",
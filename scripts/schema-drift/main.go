@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/Dionid/go-boiler/dbs/maindb/migrations"
+	"github.com/Dionid/sqli"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// pgDumpArgs are the same as of scripts/export-schema, so dumps are comparable
+var pgDumpArgs = []string{
+	"--schema-only",
+	"--schema=public",
+	"-x",
+	"--disable-triggers",
+	"--no-table-access-method",
+	"--no-owner",
+	"--no-publications",
+	"--no-comments",
+	"--no-security-labels",
+	"--no-toast-compression",
+}
+
+func main() {
+	dbUrl := flag.String("db", os.Getenv("MAIN_DB_PG"), "local Postgres where empty database is created")
+	mainDbDir := flag.String("maindb", "dbs/maindb", "checked-in generated code and schema.sql")
+	initDir := flag.String("init", "docker/main-db/init.d", "SQL applied before migrations, like in DB container")
+	pgDump := flag.String("pg-dump", "pg_dump", "pg_dump binary of the same major version as Postgres (postgres:12.9 of docker-compose)")
+	skipSchema := flag.Bool("skip-schema", false, "skip schema.sql check, generated code is still checked")
+	flag.Parse()
+
+	if *dbUrl == "" {
+		log.Fatalln("--db or MAIN_DB_PG is required")
+	}
+
+	ctx := context.Background()
+
+	// # Empty database
+	tempUrl, drop, err := createTempDatabase(ctx, *dbUrl)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if *skipSchema {
+		*pgDump = ""
+	}
+
+	drift, err := check(ctx, tempUrl, *mainDbDir, *initDir, *pgDump)
+	if dropErr := drop(); dropErr != nil {
+		log.Println("drop database:", dropErr)
+	}
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if drift != "" {
+		fmt.Print(drift)
+		fmt.Println("Schema drift: migrations don't match checked-in files, run `make prepare` and commit regenerated files")
+		os.Exit(1)
+	}
+
+	fmt.Println("No schema drift")
+}
+
+func createTempDatabase(ctx context.Context, dbUrl string) (string, func() error, error) {
+	parsed, err := url.Parse(dbUrl)
+	if err != nil {
+		return "", nil, err
+	}
+
+	admin, err := sql.Open("postgres", dbUrl)
+	if err != nil {
+		return "", nil, err
+	}
+
+	name := fmt.Sprintf("schema_drift_%d", time.Now().UnixNano())
+	parsed.Path = "/" + name
+
+	if _, err := admin.ExecContext(ctx, fmt.Sprintf(`CREATE DATABASE "%s"`, name)); err != nil {
+		admin.Close()
+		return "", nil, err
+	}
+
+	drop := func() error {
+		defer admin.Close()
+		_, err := admin.ExecContext(ctx, fmt.Sprintf(`DROP DATABASE IF EXISTS "%s" WITH (FORCE)`, name))
+		return err
+	}
+
+	return parsed.String(), drop, nil
+}
+
+// check migrates empty database and returns diff of generated code and schema dump with checked-in ones,
+// schema dump isn't checked with empty pgDump
+func check(ctx context.Context, dbUrl string, mainDbDir string, initDir string, pgDump string) (string, error) {
+	db, err := sql.Open("postgres", dbUrl)
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	// # Init scripts of DB container (extensions)
+	initFiles, err := filepath.Glob(filepath.Join(initDir, "*.sql"))
+	if err != nil {
+		return "", err
+	}
+	for _, file := range initFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		if _, err := db.ExecContext(ctx, string(content)); err != nil {
+			return "", fmt.Errorf("%s: %w", file, err)
+		}
+	}
+
+	// # Migrations
+	provider, err := migrations.NewProvider(db)
+	if err != nil {
+		return "", err
+	}
+	if _, err := provider.Up(ctx); err != nil {
+		return "", err
+	}
+
+	// # Generated code, dir name is package name
+	tempDir, err := os.MkdirTemp("", "schema-drift")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tempDir)
+
+	generatedDir := filepath.Join(tempDir, filepath.Base(mainDbDir))
+	if err := os.Mkdir(generatedDir, 0o755); err != nil {
+		return "", err
+	}
+
+	err = sqli.Generate(ctx, sqli.GenerateCmdOpts{
+		DbSchema: "public",
+		Out:      generatedDir,
+		DbUrl:    dbUrl,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate: %w", err)
+	}
+
+	drift, err := diffGenerated(mainDbDir, generatedDir)
+	if err != nil {
+		return "", err
+	}
+
+	// # Schema dump
+	if pgDump == "" {
+		return drift, nil
+	}
+
+	schemaDrift, err := diffSchema(ctx, dbUrl, filepath.Join(mainDbDir, "schema.sql"), pgDump)
+	if err != nil {
+		return "", err
+	}
+
+	return drift + schemaDrift, nil
+}
+
+func diffGenerated(checkedInDir string, generatedDir string) (string, error) {
+	checkedIn, err := filepath.Glob(filepath.Join(checkedInDir, "*.sqli.go"))
+	if err != nil {
+		return "", err
+	}
+	generated, err := filepath.Glob(filepath.Join(generatedDir, "*.sqli.go"))
+	if err != nil {
+		return "", err
+	}
+
+	names := []string{}
+	for _, file := range append(checkedIn, generated...) {
+		if name := filepath.Base(file); !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	drift := ""
+	for _, name := range names {
+		checkedInFile := filepath.Join(checkedInDir, name)
+		checkedInCode, err := readFile(checkedInFile)
+		if err != nil {
+			return "", err
+		}
+		generatedCode, err := readFile(filepath.Join(generatedDir, name))
+		if err != nil {
+			return "", err
+		}
+
+		fileDiff, err := diff(checkedInFile, checkedInCode, generatedCode)
+		if err != nil {
+			return "", err
+		}
+		drift += fileDiff
+	}
+
+	return drift, nil
+}
+
+func diffSchema(ctx context.Context, dbUrl string, schemaFile string, pgDump string) (string, error) {
+	schema, err := readFile(schemaFile)
+	if err != nil {
+		return "", err
+	}
+	if schema == "" {
+		return "", fmt.Errorf("%s isn't exported: export it with scripts/export-schema or pass --skip-schema", schemaFile)
+	}
+
+	// # Dumps of other pg_dump versions differ, so it must match server like in scripts/export-schema
+	pgDumpVersion, err := exec.CommandContext(ctx, pgDump, "--version").Output()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w: install it or pass --skip-schema", pgDump, err)
+	}
+
+	db, err := sql.Open("postgres", dbUrl)
+	if err != nil {
+		return "", err
+	}
+	defer db.Close()
+
+	serverVersion := ""
+	if err := db.QueryRowContext(ctx, "SHOW server_version").Scan(&serverVersion); err != nil {
+		return "", err
+	}
+
+	if majorVersion(string(pgDumpVersion)) != majorVersion(serverVersion) {
+		return "", fmt.Errorf(
+			"%s version %q doesn't match Postgres %s: pass --pg-dump of the same major version",
+			pgDump, strings.TrimSpace(string(pgDumpVersion)), serverVersion,
+		)
+	}
+
+	dump, err := exec.CommandContext(ctx, pgDump, append(slices.Clone(pgDumpArgs), "--dbname="+dbUrl)...).Output()
+	if err != nil {
+		return "", fmt.Errorf("pg_dump: %w", err)
+	}
+
+	return diff(schemaFile, normalizeDump(schema), normalizeDump(string(dump)))
+}
+
+// majorVersion returns major of the first version in text, like 12 of "pg_dump (PostgreSQL) 12.9"
+func majorVersion(text string) string {
+	for _, word := range strings.Fields(text) {
+		major, _, _ := strings.Cut(word, ".")
+		if major != "" && strings.Trim(major, "0123456789") == "" {
+			return major
+		}
+	}
+
+	return ""
+}
+
+// normalizeDump drops comments with pg_dump version and blank lines
+func normalizeDump(dump string) string {
+	lines := []string{}
+	for _, line := range strings.Split(dump, "\n") {
+		if line == "" || strings.HasPrefix(line, "--") || strings.HasPrefix(line, `\restrict`) || strings.HasPrefix(line, `\unrestrict`) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// readFile returns empty content of missing file
+func readFile(file string) (string, error) {
+	content, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+
+	return string(content), err
+}
+
+// diff returns unified diff of checked-in file and the one made from migrated database
+func diff(file string, checkedIn string, migrated string) (string, error) {
+	if checkedIn == migrated {
+		return "", nil
+	}
+
+	fromFile, toFile := file, "migrated "+file
+	if checkedIn == "" {
+		fromFile = "/dev/null"
+	}
+	if migrated == "" {
+		toFile = "/dev/null"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(checkedIn),
+		B:        difflib.SplitLines(migrated),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitSchemaDrift(t *testing.T) {
+	t.Run("diff", func(t *testing.T) {
+		drift, err := diff("user.sqli.go", "a\nb\n", "a\nb\n")
+		assert.Nil(t, err)
+		assert.Equal(t, "", drift)
+
+		drift, err = diff("user.sqli.go", "a\nb\n", "a\nc\n")
+		assert.Nil(t, err)
+		assert.Contains(t, drift, "--- user.sqli.go\n+++ migrated user.sqli.go\n")
+		assert.Contains(t, drift, "-b\n+c\n")
+
+		drift, err = diff("user.sqli.go", "", "a\n")
+		assert.Nil(t, err)
+		assert.Contains(t, drift, "--- /dev/null\n")
+
+		drift, err = diff("user.sqli.go", "a\n", "")
+		assert.Nil(t, err)
+		assert.Contains(t, drift, "+++ /dev/null\n")
+	})
+
+	t.Run("diff generated", func(t *testing.T) {
+		checkedInDir, generatedDir := t.TempDir(), t.TempDir()
+		write := func(dir string, name string, content string) {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+		write(checkedInDir, "user.sqli.go", "package maindb\n")
+		write(generatedDir, "user.sqli.go", "package maindb\n")
+		write(checkedInDir, "removed.sqli.go", "package maindb\n")
+		write(generatedDir, "added.sqli.go", "package maindb\n")
+		write(generatedDir, "notes.txt", "not generated\n")
+
+		drift, err := diffGenerated(checkedInDir, generatedDir)
+		assert.Nil(t, err)
+		assert.Contains(t, drift, "+++ migrated "+filepath.Join(checkedInDir, "added.sqli.go"))
+		assert.Contains(t, drift, "--- "+filepath.Join(checkedInDir, "removed.sqli.go"))
+		assert.NotContains(t, drift, "user.sqli.go")
+		assert.NotContains(t, drift, "notes.txt")
+		assert.Less(t, strings.Index(drift, "added.sqli.go"), strings.Index(drift, "removed.sqli.go"))
+	})
+
+	t.Run("normalize dump", func(t *testing.T) {
+		dump := "--\n-- PostgreSQL database dump\n--\n\\restrict abc\n\nSET statement_timeout = 0;\n\nCREATE TABLE public.user (\n    id uuid\n);\n\\unrestrict abc\n"
+		assert.Equal(t, "SET statement_timeout = 0;\nCREATE TABLE public.user (\n    id uuid\n);\n", normalizeDump(dump))
+	})
+
+	t.Run("major version", func(t *testing.T) {
+		assert.Equal(t, "12", majorVersion("pg_dump (PostgreSQL) 12.9\n"))
+		assert.Equal(t, "12", majorVersion("12.9 (Debian 12.9-1.pgdg110+1)"))
+		assert.Equal(t, "16", majorVersion("16.2"))
+		assert.Equal(t, "", majorVersion("pg_dump"))
+	})
+
+	t.Run("missing schema.sql fails", func(t *testing.T) {
+		_, err := diffSchema(t.Context(), "", filepath.Join(t.TempDir(), "schema.sql"), "pg_dump")
+		assert.ErrorContains(t, err, "--skip-schema")
+	})
+}